@@ -24,9 +24,10 @@ import (
 	"os"
 	// "os/signal"
 	"sort"
-	// "strconv"
-	// "strings"
+	"strconv"
+	"strings"
 	// "syscall"
+	"unicode/utf8"
 )
 
 
@@ -40,22 +41,71 @@ const APP_VERSION = "0.5.1"
 // Structs
 //
 type BallisticData struct {
+	altitude ParsedData
+	arrow Arrow
+	at []ParsedData
+	atmosphere Atmosphere
+	ballistic_coefficient ParsedData
+	barometric_pressure ParsedData
+	bow Bow
+	caliber ParsedData
+	drag_table DragTable
+	energy_limit float64 // Joules
 	draw_force ParsedData
 	draw_length ParsedData
 	draw_weight ParsedData
+	humidity ParsedData
 	mpbr ParsedData // max_point_blank_range ParsedData
+	mpbr_zero ZeroData
 	projectile_energy ParsedData
 	projectile_mass ParsedData
+	projectile_momentum ParsedData
 	projectile_range ParsedData
 	projectile_velocity ParsedData
 	projection_angle ParsedData
+	siege SiegeEngine
+	sight_height ParsedData
+	sling Sling
+	slingshot Slingshot
+	spine float64 // Static spine deflection of the shaft in thousandths of an inch
+	station_pressure ParsedData
+	table_start ParsedData
+	table_step ParsedData
+	table_stop ParsedData
 	target_radius ParsedData
+	temperature ParsedData
+	winds []Wind
+	zero ZeroData
+	zero_range ParsedData
+}
+
+
+type AtDistanceData struct {
+	Distance LabeledValue `json:"distance"`
+	Energy LabeledValue   `json:"energy"`
+	Momentum LabeledValue `json:"momentum"`
+	Velocity LabeledValue `json:"velocity"`
+}
+
+
+type GameData struct {
+	Energy string   `json:"energy"`
+	Momentum string `json:"momentum"`
+}
+
+
+type ConditionsData struct {
+	Altitude LabeledValue           `json:"altitude"`
+	BarometricPressure *LabeledValue `json:"barometric_pressure,omitempty"`
+	Humidity LabeledValue           `json:"humidity"`
+	Pressure LabeledValue           `json:"pressure"`
+	Temperature LabeledValue        `json:"temperature"`
 }
 
 
 type LabeledValue struct {
 	Label string       `json:"label,omitempty"`
-	ValueFloat float64 `json:"value,omitempty"`
+	ValueFloat float64 `json:"value"`
 	ValueString string `json:"value_str,omitempty"`
 }
 
@@ -65,10 +115,46 @@ type LabeledValue struct {
 // }
 
 type OutputData struct {
+	ApexHeight LabeledValue `json:"apex_height,omitempty"`
+	At []AtDistanceData   `json:"at,omitempty"`
+	Conditions *ConditionsData `json:"conditions,omitempty"`
+	DeliveredEnergy LabeledValue `json:"delivered_energy,omitempty"`
+	Efficiency LabeledValue `json:"efficiency,omitempty"`
 	Energy LabeledValue   `json:"energy,omitempty"`
+	EnergyLimit LabeledValue `json:"energy_limit,omitempty"`
+	FarZero LabeledValue  `json:"far_zero,omitempty"`
+	FOC LabeledValue      `json:"foc,omitempty"`
+	Game *GameData        `json:"game,omitempty"`
+	LimitStatus string    `json:"limit_status,omitempty"`
+	LimitVelocity LabeledValue `json:"limit_velocity,omitempty"`
+	Mass LabeledValue     `json:"mass,omitempty"`
 	Momentum LabeledValue `json:"momentum,omitempty"`
 	Mpbr LabeledValue     `json:"mpbr,omitempty"`
+	NearZero LabeledValue `json:"near_zero,omitempty"`
+	PointBlankRange LabeledValue `json:"point_blank_range,omitempty"`
+	Range LabeledValue    `json:"range,omitempty"`
+	RecommendedZero LabeledValue `json:"recommended_zero,omitempty"`
+	ReleaseAngle LabeledValue `json:"release_angle,omitempty"`
+	RequiredSpine LabeledValue `json:"required_spine,omitempty"`
+	SpineMatch string     `json:"spine_match,omitempty"`
+	StoredEnergy LabeledValue `json:"stored_energy,omitempty"`
+	Table []TableRow      `json:"table,omitempty"`
 	Velocity LabeledValue `json:"velocity,omitempty"`
+	WindCorrection LabeledValue `json:"wind_correction,omitempty"`
+	WindDrift LabeledValue      `json:"wind_drift,omitempty"`
+}
+
+
+type TableRow struct {
+	Drift LabeledValue     `json:"drift"`
+	Drop LabeledValue      `json:"drop"`
+	Elevation LabeledValue `json:"elevation"`
+	Energy LabeledValue    `json:"energy"`
+	Momentum LabeledValue  `json:"momentum"`
+	Range LabeledValue     `json:"range"`
+	Time LabeledValue      `json:"time"`
+	Velocity LabeledValue  `json:"velocity"`
+	Windage LabeledValue   `json:"windage"`
 }
 
 
@@ -79,10 +165,57 @@ var data BallisticData
 var decimal_places int = 6
 var locale_str string
 var output OutputData
+var output_angle string
+var output_click float64
 var output_debug bool = false
+var output_energy string
 var output_indent string = "    "
 var output_json bool = false
+var output_momentum string
 var output_pretty bool = false
+var output_range string
+var output_table bool = false
+var output_units string = UNIT_SYSTEM_INPUT
+var output_velocity string
+
+/** Short unit labels for table column headers */
+var unit_abbreviations = map[string]string{
+	ANGLE_LABEL_CLICKS: "clicks",
+	ANGLE_LABEL_DEGREES: "°",
+	ANGLE_LABEL_MILLIRADIANS: "mrad",
+	ANGLE_LABEL_MILS: "mil",
+	ANGLE_LABEL_MOA: "MOA",
+	ANGLE_LABEL_RADIANS: "rad",
+	ANGLE_LABEL_SMOA: "SMOA",
+	ENERGY_LABEL_FOOTPOUNDS: "ft⋅lbf",
+	ENERGY_LABEL_JOULES: "J",
+	LENGTH_LABEL_CENTIMETER: "cm",
+	LENGTH_LABEL_FOOT: "ft",
+	LENGTH_LABEL_INCH: "in",
+	LENGTH_LABEL_KILOMETER: "km",
+	LENGTH_LABEL_METER: "m",
+	LENGTH_LABEL_MILE: "mi",
+	LENGTH_LABEL_MILLIMETER: "mm",
+	LENGTH_LABEL_NAUTICAL_MILE: "NM",
+	LENGTH_LABEL_YARD: "yd",
+	MOMENTUM_LABEL_FPS: "lb⋅ft/s",
+	MOMENTUM_LABEL_MKS: "kg⋅m/s",
+	TIME_LABEL_SECONDS: "s",
+	VELOCITY_LABEL_FPS: "fps",
+	VELOCITY_LABEL_KMPH: "km/h",
+	VELOCITY_LABEL_KNOTS: "kn",
+	VELOCITY_LABEL_MPH: "mph",
+	VELOCITY_LABEL_MPS: "m/s",
+}
+
+/** Length units distances are output in for each velocity units */
+var velocity_length_labels = map[string]string{
+	VELOCITY_LABEL_FPS: LENGTH_LABEL_FOOT,
+	VELOCITY_LABEL_KMPH: LENGTH_LABEL_KILOMETER,
+	VELOCITY_LABEL_KNOTS: LENGTH_LABEL_NAUTICAL_MILE,
+	VELOCITY_LABEL_MPH: LENGTH_LABEL_MILE,
+	VELOCITY_LABEL_MPS: LENGTH_LABEL_METER,
+}
 
 
 //
@@ -95,6 +228,9 @@ func buildOutputData(data BallisticData) {
 	if data.projectile_velocity.Value > 0 {
 		output.Velocity = velocity_to_velocity(data)
 	}
+	if data.projectile_mass.Value > 0 && len(data.projectile_mass.UserLabel) == 0 {
+		output.Mass = mass_to_mass(data.projectile_mass.Value)
+	}
 	
 	output.Energy = calcKineticEnergy(data)
 	output.Momentum = calcMomentum(data)
@@ -117,40 +253,282 @@ func buildOutputData(data BallisticData) {
 		fmt.Println("") 
 	}
 
-	if InputData.Metric == false {
-		output.Energy.ValueFloat *= ENERGY_FROM_JOULES_TO_FOOTPOUNDS
-		output.Energy.Label = ENERGY_LABEL_FOOTPOUNDS
+	if data.arrow.Length > 0 {
+		output.FOC = LabeledValue{Label: PERCENT_LABEL, ValueFloat: data.arrow.FOC() * 100}
+		if output.Energy.ValueFloat > 0 {
+			output.Game = &GameData{
+				Energy: EnergyGameClass(output.Energy.ValueFloat).String(),
+				Momentum: MomentumGameClass(output.Momentum.ValueFloat).String(),
+			}
+		}
+	}
+
+	if data.energy_limit > 0 && output.Energy.ValueFloat > 0 {
+		output.LimitStatus = "within"
+		if output.Energy.ValueFloat > data.energy_limit {
+			output.LimitStatus = "exceeded"
+		}
+
+		limit_data := data
+		limit_data.projectile_velocity.Value = LimitVelocity(data.energy_limit, data.projectile_mass.Value)
+		output.EnergyLimit = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.energy_limit})
+		output.LimitVelocity = velocity_to_velocity(limit_data)
+	}
 
-		output.Momentum.ValueFloat *= MASS_FROM_KILOGRAMS_TO_POUNDS * VELOCITY_FROM_MPS_TO_FPS
-		output.Momentum.Label = MOMENTUM_LABEL_FPS
+	output.Energy = energy_to_energy(output.Energy)
+	output.Momentum = momentum_to_momentum(output.Momentum)
+
+	if data.spine > 0 || (data.arrow.Length > 0 && data.bow.Type != BOW_TYPE_CROSSBOW) {
+		required := RequiredSpine(data.bow, data.arrow)
+		if required > 0 {
+			output.RequiredSpine = LabeledValue{Label: ARROW_SPINE_LABEL, ValueFloat: required}
+			if data.spine > 0 {
+				output.SpineMatch = SpineMatch(data.spine, required)
+			}
+		}
+	}
+
+	if data.bowSet() && data.projectile_mass.Value > 0 && len(data.projectile_velocity.UserLabel) == 0 {
+		mass := data.projectile_mass.Value
+		output.StoredEnergy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.bow.StoredEnergy()})
+		output.DeliveredEnergy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.bow.DeliveredEnergy(mass)})
+		output.Efficiency = LabeledValue{Label: PERCENT_LABEL, ValueFloat: data.bow.EfficiencyFor(mass) * 100}
+	} else if data.slingshot.Bands > 0 && data.projectile_mass.Value > 0 && len(data.projectile_velocity.UserLabel) == 0 {
+		mass := data.projectile_mass.Value
+		output.StoredEnergy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.slingshot.StoredEnergy()})
+		output.DeliveredEnergy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.slingshot.DeliveredEnergy(mass)})
+		output.Efficiency = LabeledValue{Label: PERCENT_LABEL, ValueFloat: data.slingshot.EfficiencyFor(mass) * 100}
+	} else if len(data.siege.Type) > 0 && data.projectile_mass.Value > 0 && len(data.projectile_velocity.UserLabel) == 0 {
+		mass := data.projectile_mass.Value
+		output.StoredEnergy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.siege.StoredEnergy()})
+		output.DeliveredEnergy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: data.siege.DeliveredEnergy(mass)})
+		output.Efficiency = LabeledValue{Label: PERCENT_LABEL, ValueFloat: data.siege.EfficiencyFor(mass) * 100}
+	}
+	if len(data.siege.Type) > 0 && data.projection_angle.Value > 0 {
+		output.ReleaseAngle = LabeledValue{Label: ANGLE_LABEL_DEGREES, ValueFloat: data.projection_angle.Value}
 	}
 
 	if data.mpbr.Value > 0 {
 		if output_debug { fmt.Printf("MPBR %f %s\n", data.mpbr.Value, data.mpbr.Label) }
-		output.Mpbr = mpbr_to_mpbr(data)
+		output.Mpbr = length_to_length(data, data.mpbr.Value)
 		if output_debug { fmt.Printf("MPBR %f %s\n", output.Mpbr.ValueFloat, output.Mpbr.Label) }
+
+		output.RecommendedZero = length_to_length(data, data.mpbr_zero.FarZero.Distance)
+		output.NearZero = length_to_length(data, data.zero.NearZero.Distance)
+		output.ApexHeight = drift_to_drift(data.zero.Apex.Height)
+
+		if len(data.zero_range.UserLabel) > 0 {
+			output.FarZero = length_to_length(data, data.zero.FarZero.Distance)
+			output.PointBlankRange = length_to_length(data, data.zero.PointBlank.Distance)
+		}
+	}
+
+	if data.projectile_range.Value > 0 && len(data.projectile_range.UserLabel) == 0 {
+		output.Range = length_to_length(data, data.projectile_range.Value)
+	}
+
+	if len(data.winds) > 0 {
+		distance := data.mpbr.Value
+		if len(data.projectile_range.UserLabel) > 0 {
+			distance = data.projectile_range.Value
+		}
+
+		point, found := trajectoryInput(data).AtDistance(distance)
+		if found && distance > 0 {
+			output.WindDrift = drift_to_drift(point.Drift)
+			output.WindCorrection = angle_to_angle(math.Atan2(-point.Drift, distance)) // Into the wind
+		}
+	}
+
+	if data.conditionsSet() {
+		output.Conditions = buildConditions(data)
+	}
+
+	if len(data.at) > 0 && data.projectile_velocity.Value > 0 {
+		output.At = buildAtDistances(data)
+	}
+
+	if output_table && data.projectile_velocity.Value > 0 {
+		output.Table = buildTable(data)
+	}
+}
+
+
+/**
+ * Convert an angular correction in radians to the correction units
+ *
+ * Corrections are in scope clicks if a click value is given. Otherwise they
+ * default to MOA or milliradians matching the output velocity.
+ */
+func angle_to_angle(radians float64) (angle LabeledValue) {
+	if output_click > 0 {
+		angle.Label = ANGLE_LABEL_CLICKS
+		angle.ValueFloat = radians / output_click
+		return angle
+	}
+
+	label := output_angle
+	if len(label) == 0 {
+		if outputImperial() {
+			label = ANGLE_LABEL_MOA
+		} else {
+			label = ANGLE_LABEL_MILLIRADIANS
+		}
+	}
+
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_ANGLE, Value: radians * ANGLE_FROM_RADIANS_TO_DEGREES}, label)
+}
+
+
+/**
+ * Build the remaining velocity, energy and momentum at each of the --at distances
+ *
+ * Distances are in increasing order and those the projectile never reaches
+ * are left out.
+ */
+func buildAtDistances(data BallisticData) (at []AtDistanceData) {
+	distances := make([]float64, len(data.at))
+	for i, distance := range data.at {
+		distances[i] = distance.Value
+	}
+
+	for i, point := range trajectoryInput(data).AtDistances(distances) {
+		var at_distance AtDistanceData
+
+		data.projectile_velocity.Value = point.Velocity
+
+		at_distance.Distance = LabeledValue{Label: data.at[i].UserLabel, ValueFloat: data.at[i].UserValue}
+		at_distance.Velocity = velocity_to_velocity(data)
+		at_distance.Energy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: point.Energy})
+		at_distance.Momentum = momentum_to_momentum(LabeledValue{Label: MOMENTUM_LABEL_MKS, ValueFloat: point.Momentum})
+
+		at = append(at, at_distance)
+	}
+
+	if output_debug {
+		log.Printf("buildAtDistances() <| distances: %v m", distances)
+		log.Printf("buildAtDistances()  |   reached: %d of %d", len(at), len(distances))
+	}
+
+	return at
+}
+
+
+/**
+ * Build the drop table (range card) rows
+ *
+ * Ranges are from the table start to the table stop by the table step in the
+ * units of the first given. Drop is below the line of sight and drift is to
+ * the right of it. The elevation and windage are the corrections to dial,
+ * positive up and right, so they have the opposite sign to the miss.
+ */
+func buildTable(data BallisticData) (rows []TableRow) {
+	var distances []float64
+
+	range_label := tableLengthLabel(data)
+	range_unit := 1 / length_to_label(1.0, range_label).ValueFloat
+	if len(data.table_step.UserLabel) == 0 {
+		data.table_step.Value = 100 * range_unit
+	}
+	if len(data.table_stop.UserLabel) == 0 {
+		data.table_stop.Value = 1000 * range_unit
+	}
+
+	for i := 0; ; i++ {
+		distance := data.table_start.Value + data.table_step.Value * float64(i)
+		if distance > data.table_stop.Value * (1 + 1e-9) {
+			break
+		}
+		distances = append(distances, distance)
+	}
+
+	for _, point := range trajectoryInput(data).AtDistances(distances) {
+		var row TableRow
+
+		data.projectile_velocity.Value = point.Velocity
+
+		row.Range = length_to_label(point.Distance, range_label)
+		row.Velocity = velocity_to_velocity(data)
+		row.Energy = energy_to_energy(LabeledValue{Label: ENERGY_LABEL_JOULES, ValueFloat: point.Energy})
+		row.Momentum = momentum_to_momentum(LabeledValue{Label: MOMENTUM_LABEL_MKS, ValueFloat: point.Momentum})
+		row.Drop = drift_to_drift(-point.Height)
+		row.Drift = drift_to_drift(point.Drift)
+		row.Time = LabeledValue{Label: TIME_LABEL_SECONDS, ValueFloat: point.Time}
+		row.Elevation = angle_to_angle(0.0)
+		row.Windage = angle_to_angle(0.0)
+		if point.Distance > 0 {
+			row.Elevation = angle_to_angle(math.Atan2(-point.Height, point.Distance))
+			row.Windage = angle_to_angle(math.Atan2(-point.Drift, point.Distance))
+		}
+
+		rows = append(rows, row)
+	}
+
+	if output_debug {
+		log.Printf("buildTable() <| start: %12.6f m", data.table_start.Value)
+		log.Printf("buildTable() <|  stop: %12.6f m", data.table_stop.Value)
+		log.Printf("buildTable() <|  step: %12.6f m", data.table_step.Value)
+		log.Printf("buildTable()  |  rows: %d of %d", len(rows), len(distances))
 	}
+
+	return rows
 }
 
 
-/** Calculate drop in flight */
-func calcDropAtDistance(distance, velocity float64) (drop float64) {
-	flight_time := distance / velocity
-	drop = GRAVITY_MPS * 0.5 * (flight_time * flight_time)
-	return drop
+/** Build the atmospheric conditions echoed with the output */
+func buildConditions(data BallisticData) (conditions *ConditionsData) {
+	conditions = &ConditionsData{}
+
+	if len(data.altitude.UserLabel) > 0 && output_units == UNIT_SYSTEM_INPUT {
+		conditions.Altitude = LabeledValue{Label: data.altitude.UserLabel, ValueFloat: data.altitude.UserValue}
+	} else if outputMetric() {
+		conditions.Altitude = LabeledValue{Label: LENGTH_LABEL_METER, ValueFloat: data.atmosphere.Altitude}
+	} else {
+		conditions.Altitude = length_to_label(data.atmosphere.Altitude, LENGTH_LABEL_FOOT)
+	}
+
+	if len(data.barometric_pressure.UserLabel) > 0 {
+		barometric_pressure := pressure_to_pressure(data.barometric_pressure.Value)
+		if output_units == UNIT_SYSTEM_INPUT {
+			barometric_pressure = LabeledValue{Label: data.barometric_pressure.UserLabel, ValueFloat: data.barometric_pressure.UserValue}
+		}
+		conditions.BarometricPressure = &barometric_pressure
+	}
+
+	conditions.Humidity = LabeledValue{Label: PERCENT_LABEL, ValueFloat: data.atmosphere.Humidity * 100}
+	conditions.Pressure = pressure_to_pressure(data.atmosphere.Pressure)
+	conditions.Temperature = temperature_to_temperature(data.atmosphere.Temperature)
+
+	return conditions
 }
 
-func calcDistanceForDrop(drop, velocity float64) (distance float64) {
-	distance = drop * velocity
-	// G * 0.5 * Squared(distance / velocity) = drop
-	// 9.80665 * 0.5 = 4.903325 * Squared(distance / 500) = 1m
-	// 4.903325 / 1m = 4.903325
 
-	//  GRAVITY
-	//     Gm1m2
-	// F = ------
-	//       r2
-	return distance
+/** Calculate the atmosphere from the ICAO standard at altitude and any measured conditions */
+func calcAtmosphere(data BallisticData) (atmosphere Atmosphere) {
+	atmosphere = StandardAtmosphere(data.altitude.Value)
+
+	if len(data.temperature.UserLabel) > 0 {
+		atmosphere.Temperature = data.temperature.Value
+	}
+	if len(data.station_pressure.UserLabel) > 0 {
+		atmosphere.Pressure = data.station_pressure.Value
+	} else if len(data.barometric_pressure.UserLabel) > 0 {
+		atmosphere.Pressure = StationPressure(data.barometric_pressure.Value, data.altitude.Value)
+	}
+	if len(data.humidity.UserLabel) > 0 {
+		atmosphere.Humidity = data.humidity.Value
+	}
+
+	if output_debug {
+		log.Printf("calcAtmosphere()  |     altitude: %15.6f m", atmosphere.Altitude)
+		log.Printf("calcAtmosphere()  |  temperature: %15.6f K", atmosphere.Temperature)
+		log.Printf("calcAtmosphere()  |     pressure: %15.6f Pa", atmosphere.Pressure)
+		log.Printf("calcAtmosphere()  |     humidity: %15.6f", atmosphere.Humidity)
+		log.Printf("calcAtmosphere()  |  air density: %15.6f kg/m³", atmosphere.Density())
+		log.Printf("calcAtmosphere()  | speed of sound: %13.6f mps", atmosphere.SpeedOfSound())
+	}
+
+	return atmosphere
 }
 
 
@@ -187,6 +565,65 @@ func calcForce(avg_draw_weight float64) (draw_force ParsedData) {
 }
 
 
+/**
+ * Calculate the projectile mass and velocity from any two of mass, velocity, energy and momentum
+ *
+ * Mass and velocity are returned as is when given.
+ */
+func calcMassAndVelocity(data BallisticData) (projectile_mass, projectile_velocity ParsedData) {
+	projectile_mass = data.projectile_mass
+	projectile_velocity = data.projectile_velocity
+
+	mass := projectile_mass.Value
+	velocity := projectile_velocity.Value
+	energy := data.projectile_energy.Value
+	momentum := data.projectile_momentum.Value
+
+	switch {
+	case mass > 0 && velocity > 0:
+		// Nothing to solve
+	case mass > 0 && energy > 0:
+		velocity = math.Sqrt(2 * energy / mass)
+	case mass > 0 && momentum > 0:
+		velocity = momentum / mass
+	case velocity > 0 && energy > 0:
+		mass = 2 * energy / (velocity * velocity)
+	case velocity > 0 && momentum > 0:
+		mass = momentum / velocity
+	case energy > 0 && momentum > 0:
+		velocity = 2 * energy / momentum
+		mass = momentum / velocity
+	}
+
+	if projectile_mass.Value == 0 && mass > 0 {
+		projectile_mass.Value = mass
+		projectile_mass.Label = "kilogram"
+	}
+	if projectile_velocity.Value == 0 && velocity > 0 {
+		projectile_velocity.Value = velocity
+		projectile_velocity.Label = VELOCITY_LABEL_MPS
+
+		// Velocity units follow the mass units when given
+		if len(InputData.Velocity) == 0 && len(InputData.Mass) == 0 {
+			if InputData.Metric {
+				InputData.Velocity = VELOCITY_LABEL_MPS
+			} else {
+				InputData.Velocity = VELOCITY_LABEL_FPS
+			}
+		}
+	}
+
+	if output_debug {
+		log.Printf("calcMassAndVelocity() <|   energy: %15.6f J", energy)
+		log.Printf("calcMassAndVelocity() <| momentum: %15.6f N⋅s", momentum)
+		log.Printf("calcMassAndVelocity()  |     mass: %15.6f kg", projectile_mass.Value)
+		log.Printf("calcMassAndVelocity()  | velocity: %15.6f mps", projectile_velocity.Value)
+	}
+
+	return projectile_mass, projectile_velocity
+}
+
+
 /** Calculate momentum */
 func calcMomentum(data BallisticData) (momentum LabeledValue) {
 	// kg⋅m/s (kilogram meters per second)
@@ -206,57 +643,80 @@ func calcMomentum(data BallisticData) (momentum LabeledValue) {
 }
 
 
-/** Calculate Maximum Point Blank Range */
-func calcMPBR(data BallisticData) (mpbr ParsedData) {
-	distance := 0.0
-	diameter := data.target_radius.Value * 2
-	drop := data.target_radius.Value
-	velocity := data.projectile_velocity.Value
+/**
+ * Calculate Maximum Point Blank Range
+ *
+ * The sight is zeroed so the apex of the trajectory is the target radius above
+ * the line of sight. The far zero of that trajectory is the recommended zero.
+ */
+func calcMPBR(data BallisticData) (zero ZeroData) {
+	trajectory := trajectoryInput(data)
+	trajectory.Angle = trajectory.PointBlankAngle(data.target_radius.Value)
+
+	zero = trajectory.Zero(data.target_radius.Value)
 
-	for drop < diameter || drop == 0.0 {
-		distance += 1.0
-		drop = calcDropAtDistance(distance, velocity)
-	}
-	for drop > diameter {
-		distance -= 0.1
-		drop = calcDropAtDistance(distance, velocity)
-	}
-	for drop < diameter {
-		distance += 0.01
-		drop = calcDropAtDistance(distance, velocity)
-	}
-	for drop > diameter {
-		distance -= 0.001
-		drop = calcDropAtDistance(distance, velocity)
-	}
-	for drop < diameter {
-		distance += 0.0001
-		drop = calcDropAtDistance(distance, velocity)
-	}
-	for drop > diameter {
-		distance -= 0.00001
-		drop = calcDropAtDistance(distance, velocity)
+	if output_debug {
+		log.Printf("calcMPBR() <|    target radius: %12.6f m", data.target_radius.Value)
+		log.Printf("calcMPBR() <|     sight height: %12.6f m", trajectory.SightHeight)
+		log.Printf("calcMPBR()  |       bore angle: %12.6f rad", zero.Angle)
+		log.Printf("calcMPBR()  |        near zero: %12.6f m", zero.NearZero.Distance)
+		log.Printf("calcMPBR()  | recommended zero: %12.6f m", zero.FarZero.Distance)
+		log.Printf("calcMPBR()  |             apex: %12.6f m", zero.Apex.Height)
+		log.Printf("calcMPBR()  |   time of flight: %12.6f s", zero.PointBlank.Time)
+		log.Printf("calcMPBR()  |             MPBR: %12.6f m", zero.PointBlank.Distance)
 	}
-	for drop < diameter {
-		distance += 0.000001
-		drop = calcDropAtDistance(distance, velocity)
+
+	return zero
+}
+
+
+/**
+ * Calculate the distance to impact on a horizontal plane given the projection angle
+ *
+ * Siege engines release above the ground so the projectile falls below the
+ * release point before it lands.
+ */
+func calcRange(data BallisticData) (projectile_range ParsedData) {
+	point, found := rangeInput(data).AtDrop(-data.siege.Height())
+	if found {
+		projectile_range.Value = point.Distance
+		projectile_range.Label = LENGTH_LABEL_METER
 	}
-	for drop > diameter {
-		distance -= 0.0000001
-		drop = calcDropAtDistance(distance, velocity)
+
+	if output_debug {
+		log.Printf("calcRange() <|   release height: %15.6f m", data.siege.Height())
+		log.Printf("calcRange()  |   time of flight: %15.6f s", point.Time)
+		log.Printf("calcRange()  | projectile range: %15.6f m", projectile_range.Value)
 	}
 
-	mpbr.Value = distance
-	mpbr.Label = "meters"
+	return projectile_range
+}
+
+
+/**
+ * Calculate sectional density
+ *
+ * This is the ballistic coefficient of a projectile with a form factor of 1
+ * and is used with custom drag curves measured for the projectile itself.
+ */
+func calcSectionalDensity(data BallisticData) (sectional_density ParsedData) {
+	caliber := data.caliber.Value
+
+	if caliber > 0 {
+		sectional_density.Value = data.projectile_mass.Value / (caliber * caliber)
+		sectional_density.Label = BALLISTIC_COEFFICIENT_LABEL_KGPM2
+	}
 
-	// log.Printf("calcMPBR() <|   target radius: %12.6f m", data.target_radius.Value)
-	// log.Printf("calcMPBR() <| target diameter: %12.6f m", diameter)
-	// log.Printf("calcMPBR() <| projectile drop: %12.6f m", drop)
-	// log.Printf("calcMPBR()  |            MPBR: %12.6f m", distance)
+	if output_debug {
+		log.Printf("calcSectionalDensity() <|   projectile mass: %15.6f kg", data.projectile_mass.Value)
+		log.Printf("calcSectionalDensity() <|           caliber: %15.6f m", caliber)
+		log.Printf("calcSectionalDensity()  | sectional density: %15.6f %s", sectional_density.Value, sectional_density.Label)
+	}
 
-	return mpbr
+	return sectional_density
 }
 
+
 /**
  * Calculate velocity
  */
@@ -289,13 +749,12 @@ func calcVelocity(data BallisticData) (projectile_velocity ParsedData) {
 }
 
 
-/** Calculate the initial velocity of a projectile */
-func calcVelocityInitial(data BallisticData) (initial_velocity ParsedData) {
-	radians := data.projection_angle.Value * ANGLE_DEGREES_TO_RADIANS
-	sin := math.Sin(2 * radians)
-	Rg := data.projectile_range.Value * GRAVITY_MPS
-	initial_velocity.Value = math.Sqrt(Rg/sin)
-	initial_velocity.Label = VELOCITY_LABEL_MPS
+/** Calculate arrow velocity from the energy stored in the bow */
+func calcBowVelocity(data BallisticData) (projectile_velocity ParsedData) {
+	bow := data.bow
+
+	projectile_velocity.Value = bow.ArrowVelocity(data.projectile_mass.Value)
+	projectile_velocity.Label = VELOCITY_LABEL_MPS
 
 	if len(InputData.Velocity) == 0 {
 		if InputData.Metric {
@@ -306,160 +765,716 @@ func calcVelocityInitial(data BallisticData) (initial_velocity ParsedData) {
 	}
 
 	if output_debug {
-		log.Printf("calcVelocityInitial()  | initial velocity: %15.6f mps", initial_velocity.Value)
+		log.Printf("calcBowVelocity() <|            bow type: %s", bow.Type)
+		log.Printf("calcBowVelocity() <|         draw weight: %15.6f N", bow.DrawWeight)
+		log.Printf("calcBowVelocity() <|         draw length: %15.6f m", bow.DrawLength)
+		log.Printf("calcBowVelocity() <|        brace height: %15.6f m", bow.BraceHeight)
+		log.Printf("calcBowVelocity() <|             let-off: %15.6f", bow.LetOff)
+		log.Printf("calcBowVelocity() <|        virtual mass: %15.6f kg", bow.VirtualMass)
+		log.Printf("calcBowVelocity()  |          efficiency: %15.6f", bow.EfficiencyFor(data.projectile_mass.Value))
+		log.Printf("calcBowVelocity() <|   draw curve points: %d", len(bow.Curve))
+		log.Printf("calcBowVelocity()  |        power stroke: %15.6f m", bow.PowerStroke())
+		log.Printf("calcBowVelocity()  |       stored energy: %15.6f J", bow.StoredEnergy())
+		log.Printf("calcBowVelocity()  | projectile velocity: %15.6f mps", projectile_velocity.Value)
 	}
 
-	return initial_velocity
+	return projectile_velocity
 }
 
 
-/**
- * Cleans up OutputData for JSON parsing
- *
- * Removes top level keys from OutputData when the child fields have no value.
- * This is necessary because the standard JSON package does not check child
- * structs if they are empty or not.
- */
-func cleanupJSON(data OutputData) (data_obj map[string]LabeledValue) {
-	// data_obj = make(map[string]interface{})
-	data_obj = make(map[string]LabeledValue)
+/** Calculate arrow velocity from the IBO/ATA speed rating of the bow */
+func calcRatedVelocity(data BallisticData) (projectile_velocity ParsedData) {
+	bow := data.bow
 
-	if data.Energy.ValueFloat != 0 {
-		data_obj["energy"] = data.Energy
-	}
-	if data.Momentum.ValueFloat != 0 {
-		data_obj["momentum"] = data.Momentum
-	}
-	if data.Mpbr.ValueFloat != 0 {
-		data_obj["mpbr"] = data.Mpbr
+	projectile_velocity.Value = bow.RatedVelocity(data.projectile_mass.Value)
+	projectile_velocity.Label = VELOCITY_LABEL_MPS
+
+	if len(InputData.Velocity) == 0 {
+		if InputData.Metric {
+			InputData.Velocity = VELOCITY_LABEL_MPS
+		} else {
+			InputData.Velocity = VELOCITY_LABEL_FPS
+		}
 	}
-	if data.Velocity.ValueFloat != 0 {
-		data_obj["velocity"] = data.Velocity
+
+	if output_debug {
+		log.Printf("calcRatedVelocity() <|        speed rating: %15.6f mps", bow.SpeedRating)
+		log.Printf("calcRatedVelocity() <|         draw weight: %15.6f N", bow.DrawWeight)
+		log.Printf("calcRatedVelocity() <|         draw length: %15.6f m", bow.DrawLength)
+		log.Printf("calcRatedVelocity() <|     projectile mass: %15.6f kg", data.projectile_mass.Value)
+		log.Printf("calcRatedVelocity() <|         string mass: %15.6f kg", bow.StringMass)
+		log.Printf("calcRatedVelocity()  | projectile velocity: %15.6f mps", projectile_velocity.Value)
 	}
 
-	return data_obj
+	return projectile_velocity
 }
 
 
-/** Function defined by a call to github.com/runeimp/locale.NumberFormatter() */
-var locale_NumberFormatter func(number float64, scale int) string
+/** Calculate ammo velocity from the energy stored in the slingshot bands */
+func calcSlingshotVelocity(data BallisticData) (projectile_velocity ParsedData) {
+	slingshot := data.slingshot
 
+	projectile_velocity.Value = slingshot.ReleaseVelocity(data.projectile_mass.Value)
+	projectile_velocity.Label = VELOCITY_LABEL_MPS
 
-/** Returns the largest integer in the list of arguments */
-func maxInt(nums ...int) (max_int int) {
-	// max_int = math.MinInt64 // ./ballistic.go:350:10: constant -9223372036854775808 overflows int when compiling for Win32
-	max_int = math.MinInt32
-	
-	for _, num := range nums {
-		if max_int < num {
-			max_int = num
+	if len(InputData.Velocity) == 0 {
+		if InputData.Metric {
+			InputData.Velocity = VELOCITY_LABEL_MPS
+		} else {
+			InputData.Velocity = VELOCITY_LABEL_FPS
 		}
 	}
-	return max_int
+
+	if output_debug {
+		log.Printf("calcSlingshotVelocity() <|       band material: %s", slingshot.Material.Name)
+		log.Printf("calcSlingshotVelocity() <|         band length: %15.6f m", slingshot.Length)
+		log.Printf("calcSlingshotVelocity() <|          band width: %15.6f m", slingshot.Width)
+		log.Printf("calcSlingshotVelocity() <|          band taper: %15.6f", slingshot.Taper)
+		log.Printf("calcSlingshotVelocity() <|      band thickness: %15.6f m", slingshot.Thickness)
+		log.Printf("calcSlingshotVelocity() <|          hysteresis: %15.6f", slingshot.Hysteresis)
+		log.Printf("calcSlingshotVelocity() <|         draw length: %15.6f m", slingshot.DrawLength)
+		log.Printf("calcSlingshotVelocity()  |          draw force: %15.6f N", slingshot.DrawForce())
+		log.Printf("calcSlingshotVelocity()  |           band mass: %15.6f kg", slingshot.BandMass())
+		log.Printf("calcSlingshotVelocity()  |       stored energy: %15.6f J", slingshot.StoredEnergy())
+		log.Printf("calcSlingshotVelocity()  | projectile velocity: %15.6f mps", projectile_velocity.Value)
+	}
+
+	return projectile_velocity
 }
 
 
-/** Convert MPBR in meters to input units */
-func mpbr_to_mpbr(data BallisticData) (mpbr LabeledValue) {
-	mpbr.Label = ""
-	mpbr.ValueFloat = 0.0
+/** Calculate stone velocity from the speed of the end of the sling cord */
+func calcSlingVelocity(data BallisticData) (projectile_velocity ParsedData) {
+	sling := data.sling
 
-	user_label := data.projectile_velocity.UserLabel
-	if len(user_label) == 0 {
-		user_label = InputData.Velocity
-	}
+	projectile_velocity.Value = sling.ReleaseVelocity()
+	projectile_velocity.Label = VELOCITY_LABEL_MPS
 
-	switch user_label {
-	case VELOCITY_LABEL_FPS:
-		mpbr.Label = LENGTH_LABEL_FOOT
-		mpbr.ValueFloat = data.mpbr.Value * LENGTH_FROM_METERS_TO_FEET
-	case VELOCITY_LABEL_KMPH:
-		mpbr.Label = LENGTH_LABEL_KILOMETER
-		mpbr.ValueFloat = data.mpbr.Value * LENGTH_FROM_METERS_TO_KILOMETERS
-	case VELOCITY_LABEL_KNOTS:
-		mpbr.Label = LENGTH_LABEL_NAUTICAL_MILE
-		mpbr.ValueFloat = data.mpbr.Value * LENGTH_FROM_METERS_TO_NAUTICAL_MILES
-	case VELOCITY_LABEL_MPS:
-		mpbr.Label = LENGTH_LABEL_METER
-		mpbr.ValueFloat = data.mpbr.Value
-	case VELOCITY_LABEL_MPH:
-		mpbr.Label = LENGTH_LABEL_MILE
-		mpbr.ValueFloat = data.mpbr.Value * LENGTH_FROM_METERS_TO_MILES
+	if len(InputData.Velocity) == 0 {
+		if InputData.Metric {
+			InputData.Velocity = VELOCITY_LABEL_MPS
+		} else {
+			InputData.Velocity = VELOCITY_LABEL_FPS
+		}
 	}
 
 	if output_debug {
-		log.Printf("mpbr_to_mpbr()  <|  projectile velocity: %s", data.projectile_velocity.UserLabel)
-		log.Printf("mpbr_to_mpbr()  <| InputData velocity: %s", InputData.Velocity)
-		log.Printf("mpbr_to_mpbr()   |  projectile velocity: %15.6f mps", mpbr.Label, mpbr.Label)
+		log.Printf("calcSlingVelocity() <|         cord length: %15.6f m", sling.Length)
+		log.Printf("calcSlingVelocity() <|         revolutions: %15.6f rps", sling.Revolutions)
+		log.Printf("calcSlingVelocity()  | projectile velocity: %15.6f mps", projectile_velocity.Value)
 	}
 
-	return mpbr
-}
-
-
-/** Takes a float64 and returns it's formated number value and it's string width */
-func numberFormatter(number float64) (value string, width int) {
-	value = locale_NumberFormatter(number, decimal_places)
-	width = len(value)
-
-	return value, width
+	return projectile_velocity
 }
 
 
-/** Print Human Readable Output */
-func outputHuman(data OutputData) {
-	fmt.Println("")
+/** Calculate projectile velocity from the energy stored in the trebuchet counterweight or catapult arm */
+func calcSiegeVelocity(data BallisticData) (projectile_velocity ParsedData) {
+	engine := data.siege
 
-	var energy_value string
-	var energy_width int
-	var momentum_value string
-	var momentum_width int
-	var mpbr_value string
-	var mpbr_width int
-	var velocity_value string
-	var velocity_width int
+	projectile_velocity.Value = engine.ReleaseVelocity(data.projectile_mass.Value)
+	projectile_velocity.Label = VELOCITY_LABEL_MPS
 
-	if data.Velocity.ValueFloat > 0 {
-		velocity_value, velocity_width = numberFormatter(data.Velocity.ValueFloat)
-	}
-	if data.Energy.ValueFloat > 0 {
-		energy_value, energy_width = numberFormatter(data.Energy.ValueFloat)
-	}
-	if data.Momentum.ValueFloat > 0 {
-		momentum_value, momentum_width = numberFormatter(data.Momentum.ValueFloat)
+	if len(InputData.Velocity) == 0 {
+		if InputData.Metric {
+			InputData.Velocity = VELOCITY_LABEL_MPS
+		} else {
+			InputData.Velocity = VELOCITY_LABEL_FPS
+		}
 	}
-	if data.Mpbr.ValueFloat > 0 {
-		mpbr_value, mpbr_width = numberFormatter(data.Mpbr.ValueFloat)
+
+	if output_debug {
+		log.Printf("calcSiegeVelocity() <|         engine type: %s", engine.Type)
+		log.Printf("calcSiegeVelocity() <|       counterweight: %15.6f kg", engine.Counterweight)
+		log.Printf("calcSiegeVelocity() <|            long arm: %15.6f m", engine.LongArm)
+		log.Printf("calcSiegeVelocity() <|           short arm: %15.6f m", engine.ShortArm)
+		log.Printf("calcSiegeVelocity() <|               sling: %15.6f m", engine.Sling)
+		log.Printf("calcSiegeVelocity() <|         draw weight: %15.6f N", engine.DrawWeight)
+		log.Printf("calcSiegeVelocity() <|         draw length: %15.6f m", engine.DrawLength)
+		log.Printf("calcSiegeVelocity()  |         drop height: %15.6f m", engine.DropHeight())
+		log.Printf("calcSiegeVelocity()  |       stored energy: %15.6f J", engine.StoredEnergy())
+		log.Printf("calcSiegeVelocity()  |          efficiency: %15.6f", engine.EfficiencyFor(data.projectile_mass.Value))
+		log.Printf("calcSiegeVelocity()  | projectile velocity: %15.6f mps", projectile_velocity.Value)
 	}
 
-	max_width := fmt.Sprintf("%d", maxInt(
-		velocity_width,
-		energy_width,
-		momentum_width,
-		mpbr_width,
-	))
+	return projectile_velocity
+}
 
 
-	if velocity_width > 0 {
-		msg_format := "  Projectile Velocity: %" + max_width + "s %s\n"
-		fmt.Printf(msg_format, velocity_value, data.Velocity.Label)
+/**
+ * Calculate the initial velocity of a projectile
+ *
+ * Found is false if drag keeps the projectile from reaching the range.
+ */
+func calcVelocityInitial(data BallisticData) (initial_velocity ParsedData, found bool) {
+	radians := data.projection_angle.Value * ANGLE_DEGREES_TO_RADIANS
+	sin := math.Sin(2 * radians)
+	Rg := data.projectile_range.Value * GRAVITY_MPS
+	initial_velocity.Value = math.Sqrt(Rg/sin)
+	initial_velocity.Label = VELOCITY_LABEL_MPS
+
+	if data.drag_table != nil && data.ballistic_coefficient.Value > 0 {
+		if initial_velocity.Value, found = solveVelocityForRange(data, initial_velocity.Value); ! found {
+			return initial_velocity, false
+		}
+	}
+
+	if len(InputData.Velocity) == 0 {
+		if InputData.Metric {
+			InputData.Velocity = VELOCITY_LABEL_MPS
+		} else {
+			InputData.Velocity = VELOCITY_LABEL_FPS
+		}
+	}
+
+	if output_debug {
+		log.Printf("calcVelocityInitial()  | initial velocity: %15.6f mps", initial_velocity.Value)
+	}
+
+	return initial_velocity, true
+}
+
+
+/**
+ * Cleans up OutputData for JSON parsing
+ *
+ * Removes top level keys from OutputData when the child fields have no value.
+ * This is necessary because the standard JSON package does not check child
+ * structs if they are empty or not.
+ */
+func cleanupJSON(data OutputData) (data_obj map[string]interface{}) {
+	data_obj = make(map[string]interface{})
+
+	if data.ApexHeight.ValueFloat != 0 {
+		data_obj["apex_height"] = data.ApexHeight
 	}
-	if energy_width > 0 {
-		msg_format := "    Projectile Energy: %" + max_width + "s %s\n"
-		fmt.Printf(msg_format, energy_value, data.Energy.Label)
+	if len(data.At) > 0 {
+		data_obj["at"] = data.At
 	}
-	if momentum_width > 0 {
-		msg_format := "  Projectile Momentum: %" + max_width + "s %s\n"
-		fmt.Printf(msg_format, momentum_value, data.Momentum.Label)
+	if data.Conditions != nil {
+		data_obj["conditions"] = data.Conditions
 	}
-	if mpbr_width > 0 {
-		msg_format := "Max Point Blank Range: %" + max_width + "s %s\n"
-		fmt.Printf(msg_format, mpbr_value, data.Mpbr.Label)
+	if data.DeliveredEnergy.ValueFloat != 0 {
+		data_obj["delivered_energy"] = data.DeliveredEnergy
+		data_obj["efficiency"] = data.Efficiency
+		data_obj["stored_energy"] = data.StoredEnergy
 	}
+	if data.Energy.ValueFloat != 0 {
+		data_obj["energy"] = data.Energy
+	}
+	if len(data.LimitStatus) > 0 {
+		data_obj["energy_limit"] = data.EnergyLimit
+		data_obj["limit_status"] = data.LimitStatus
+		data_obj["limit_velocity"] = data.LimitVelocity
+	}
+	if data.FarZero.ValueFloat != 0 {
+		data_obj["far_zero"] = data.FarZero
+	}
+	if len(data.FOC.Label) > 0 {
+		data_obj["foc"] = data.FOC
+	}
+	if data.Game != nil {
+		data_obj["game"] = data.Game
+	}
+	if data.Momentum.ValueFloat != 0 {
+		data_obj["momentum"] = data.Momentum
+	}
+	if data.Mass.ValueFloat != 0 {
+		data_obj["mass"] = data.Mass
+	}
+	if data.Mpbr.ValueFloat != 0 {
+		data_obj["mpbr"] = data.Mpbr
+	}
+	if data.NearZero.ValueFloat != 0 {
+		data_obj["near_zero"] = data.NearZero
+	}
+	if data.PointBlankRange.ValueFloat != 0 {
+		data_obj["point_blank_range"] = data.PointBlankRange
+	}
+	if data.Range.ValueFloat != 0 {
+		data_obj["range"] = data.Range
+	}
+	if data.RecommendedZero.ValueFloat != 0 {
+		data_obj["recommended_zero"] = data.RecommendedZero
+	}
+	if data.ReleaseAngle.ValueFloat != 0 {
+		data_obj["release_angle"] = data.ReleaseAngle
+	}
+	if data.RequiredSpine.ValueFloat != 0 {
+		data_obj["required_spine"] = data.RequiredSpine
+	}
+	if len(data.SpineMatch) > 0 {
+		data_obj["spine_match"] = data.SpineMatch
+	}
+	if data.Velocity.ValueFloat != 0 {
+		data_obj["velocity"] = data.Velocity
+	}
+	if len(data.Table) > 0 {
+		data_obj["table"] = data.Table
+	}
+	if len(data.WindDrift.Label) > 0 {
+		data_obj["wind_correction"] = data.WindCorrection
+		data_obj["wind_drift"] = data.WindDrift
+	}
+
+	return data_obj
+}
+
+
+/** Convert energy in joules to the --out-energy units or units matching the output system */
+func energy_to_energy(joules LabeledValue) (energy LabeledValue) {
+	label := output_energy
+	if len(label) == 0 {
+		label = ENERGY_LABEL_JOULES
+		if outputMetric() == false {
+			label = ENERGY_LABEL_FOOTPOUNDS
+		}
+	}
+
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_ENERGY, Value: joules.ValueFloat}, label)
+}
+
+
+/** Function defined by a call to github.com/runeimp/locale.NumberFormatter() */
+var locale_NumberFormatter func(number float64, scale int) string
+
+
+/** Convert a drift or drop in meters to inches or centimeters matching the output velocity */
+func drift_to_drift(meters float64) (drift LabeledValue) {
+	if outputImperial() {
+		return length_to_label(meters, LENGTH_LABEL_INCH)
+	}
+
+	return length_to_label(meters, LENGTH_LABEL_CENTIMETER)
+}
+
+
+/** Returns the largest integer in the list of arguments */
+func maxInt(nums ...int) (max_int int) {
+	// max_int = math.MinInt64 // ./ballistic.go:350:10: constant -9223372036854775808 overflows int when compiling for Win32
+	max_int = math.MinInt32
 	
+	for _, num := range nums {
+		if max_int < num {
+			max_int = num
+		}
+	}
+	return max_int
+}
+
+
+/** Convert a mass in kilograms to grains or grams matching the output velocity */
+func mass_to_mass(kilograms float64) (mass LabeledValue) {
+	label := MASS_LABEL_GRAMS
+	if outputImperial() {
+		label = MASS_LABEL_GRAINS
+	}
+
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_MASS, Value: kilograms}, label)
+}
+
+
+/** Convert momentum in meter kilograms per second to the --out-momentum units or units matching the output system */
+func momentum_to_momentum(mks LabeledValue) (momentum LabeledValue) {
+	label := output_momentum
+	if len(label) == 0 {
+		label = MOMENTUM_LABEL_MKS
+		if outputMetric() == false {
+			label = MOMENTUM_LABEL_FPS
+		}
+	}
+
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_MOMENTUM, Value: mks.ValueFloat}, label)
+}
+
+
+
+
+/**
+ * Convert a distance in meters to the --out-range units or the units matching the output
+ *
+ * Distances are in meters or feet for metric or imperial output. Otherwise
+ * they are in the units of the zero range or distance given, or match the
+ * --out-velocity or input velocity so knots are in nautical miles.
+ */
+func length_to_length(data BallisticData, meters float64) (length LabeledValue) {
+	user_label := output_velocity
+	if len(user_label) == 0 {
+		user_label = data.projectile_velocity.UserLabel
+	}
+	if len(user_label) == 0 {
+		user_label = InputData.Velocity
+	}
+
+	length_label, found := velocity_length_labels[user_label]
+	switch {
+	case len(output_range) > 0:
+		length_label, found = output_range, true
+	case output_units == UNIT_SYSTEM_METRIC:
+		length_label, found = LENGTH_LABEL_METER, true
+	case output_units == UNIT_SYSTEM_IMPERIAL:
+		length_label, found = LENGTH_LABEL_FOOT, true
+	case len(data.zero_range.UserLabel) > 0:
+		length_label, found = data.zero_range.UserLabel, true
+	case len(data.projectile_range.UserLabel) > 0:
+		length_label, found = data.projectile_range.UserLabel, true
+	}
+	if found {
+		length = length_to_label(meters, length_label)
+	}
+
+	if output_debug {
+		log.Printf("length_to_length()  <|  projectile velocity: %s", data.projectile_velocity.UserLabel)
+		log.Printf("length_to_length()  <|   InputData velocity: %s", InputData.Velocity)
+		log.Printf("length_to_length()   |               length: %15.6f %s", length.ValueFloat, length.Label)
+	}
+
+	return length
+}
+
+
+/** Convert a distance in meters to the given length units */
+func length_to_label(meters float64, label string) (length LabeledValue) {
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_LENGTH, Value: meters}, label)
+}
+
+
+/** Convert a quantity to the units with the label or the default units of its dimension for unknown labels */
+func quantity_to_label(quantity Quantity, label string) (labeled LabeledValue) {
+	unit, found := UnitByLabel(quantity.Dimension, label)
+	if ! found {
+		unit, _ = DefaultUnit(quantity.Dimension)
+	}
+
+	labeled.Label = unit.Designation
+	labeled.ValueFloat, _ = quantity.In(unit)
+
+	return labeled
+}
+
+
+/** Takes a float64 and returns it's formated number value and it's string width */
+func numberFormatter(number float64) (value string, width int) {
+	if math.Abs(number) < 0.5 * math.Pow10(-decimal_places) {
+		number = 0 // No -0.00 for values that round to zero
+	}
+	value = locale_NumberFormatter(number, decimal_places)
+	width = len(value)
+
+	return value, width
+}
+
+
+/** Convert pressure in pascals to input units or units matching the output system */
+func pressure_to_pressure(pascals float64) (pressure LabeledValue) {
+	var user_label string
+	if output_units == UNIT_SYSTEM_INPUT {
+		user_label = InputData.Pressure
+	}
+	if len(user_label) == 0 {
+		if outputMetric() {
+			user_label = PRESSURE_LABEL_HECTOPASCALS
+		} else {
+			user_label = PRESSURE_LABEL_INCHES_OF_MERCURY
+		}
+	}
+
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_PRESSURE, Value: pascals}, user_label)
+}
+
+
+/** Returns true if the output is in imperial units for the output system or the output velocity */
+func outputImperial() bool {
+	switch output_units {
+	case UNIT_SYSTEM_IMPERIAL:
+		return true
+	case UNIT_SYSTEM_METRIC:
+		return false
+	}
+
+	unit, _ := UnitByLabel(VALUE_TYPE_VELOCITY, output.Velocity.Label)
+	return unit.System == UNIT_SYSTEM_IMPERIAL
+}
+
+
+/** Returns true if the output is in metric units for the output system or the input velocity or mass */
+func outputMetric() bool {
+	switch output_units {
+	case UNIT_SYSTEM_IMPERIAL:
+		return false
+	case UNIT_SYSTEM_METRIC:
+		return true
+	}
+
+	// The projectile units decide rather than whichever value was given last
+	for _, input := range [][2]string{{VALUE_TYPE_VELOCITY, InputData.Velocity}, {VALUE_TYPE_MASS, InputData.Mass}} {
+		if unit, found := UnitByLabel(input[0], input[1]); found && len(unit.System) > 0 {
+			return unit.System == UNIT_SYSTEM_METRIC
+		}
+	}
+
+	return InputData.Metric
+}
+
+
+/**
+ * Parse wind zones
+ *
+ * Zones are comma separated as SPEED@DIRECTION:UNTIL where UNTIL is the
+ * distance the wind blows until. The last zone may leave out UNTIL to blow all
+ * the way to the target. A zone without a direction is a full value wind from
+ * 3 o'clock.
+ */
+func parseWinds(zones string) (winds []Wind, err error) {
+	// Wind speeds should not change the units of the projectile output
+	input_units := InputData
+	defer func() { InputData = input_units }()
+
+	zone_list := strings.Split(zones, ",")
+	for z, zone := range zone_list {
+		var wind Wind
+		zone = strings.TrimSpace(zone)
+		given := zone
+
+		if i := strings.Index(zone, ":"); i >= 0 {
+			if len(strings.TrimSpace(zone[i + 1:])) == 0 {
+				return nil, fmt.Errorf("The wind zone %q has no distance it blows until. Give it as SPEED@DIRECTION:UNTIL", given)
+			}
+			until, err := ParsePositiveValue(zone[i + 1:], VALUE_TYPE_LENGTH)
+			if err != nil {
+				return nil, err
+			}
+			if len(winds) > 0 && until.Value <= winds[len(winds) - 1].Until {
+				return nil, fmt.Errorf("Wind zone distances must increase. %q is not beyond the zone before it", zone)
+			}
+			wind.Until = until.Value
+			zone = zone[:i]
+		} else if z < len(zone_list) - 1 {
+			return nil, fmt.Errorf("Only the last wind zone may blow all the way to the target. Give the distance %q blows until as SPEED@DIRECTION:UNTIL", zone)
+		}
+
+		direction := "3oclock"
+		if i := strings.Index(zone, "@"); i >= 0 {
+			direction = zone[i + 1:]
+			zone = zone[:i]
+			if len(strings.TrimSpace(direction)) == 0 {
+				return nil, fmt.Errorf("The wind zone %q has no direction after the @. Give it as SPEED@DIRECTION", given)
+			}
+		}
+		if len(strings.TrimSpace(zone)) == 0 {
+			return nil, fmt.Errorf("The wind zone %q has no speed. Give it as SPEED@DIRECTION", given)
+		}
+
+		speed, err := ParseValue(zone, VALUE_TYPE_VELOCITY)
+		if err != nil {
+			return nil, err
+		}
+		angle, err := ParseValue(direction, VALUE_TYPE_ANGLE)
+		if err != nil {
+			return nil, err
+		}
+		wind.Speed = speed.Value
+		wind.Direction = angle.Value * ANGLE_DEGREES_TO_RADIANS
+
+		if output_debug {
+			log.Printf("parseWinds()  | speed: %12.6f mps | direction: %12.6f radians | until: %12.6f m", wind.Speed, wind.Direction, wind.Until)
+		}
+
+		winds = append(winds, wind)
+	}
+
+	return winds, nil
+}
+
+
+/** Print labeled values right aligned on their labels and number widths */
+func printLabeledValues(labels []string, values []LabeledValue) {
+	numbers := make([]string, len(values))
+	widths := make([]int, len(values))
+
+	for i, value := range values {
+		numbers[i], widths[i] = numberFormatter(value.ValueFloat)
+	}
+
+	max_width := fmt.Sprintf("%d", maxInt(widths...))
+
+	for i, value := range values {
+		msg_format := "%21s: %" + max_width + "s %s\n"
+		fmt.Printf(msg_format, labels[i], numbers[i], value.Label)
+	}
+}
+
+
+/** Print Human Readable Output */
+func outputHuman(data OutputData) {
+	fmt.Println("")
+
+	var labels []string
+	var values []LabeledValue
+
+	if data.Mass.ValueFloat > 0 {
+		labels = append(labels, "Projectile Mass")
+		values = append(values, data.Mass)
+	}
+	if len(data.FOC.Label) > 0 {
+		labels = append(labels, "FOC")
+		values = append(values, data.FOC)
+	}
+	if data.Velocity.ValueFloat > 0 {
+		labels = append(labels, "Projectile Velocity")
+		values = append(values, data.Velocity)
+	}
+	if data.Energy.ValueFloat > 0 {
+		labels = append(labels, "Projectile Energy")
+		values = append(values, data.Energy)
+	}
+	if data.Momentum.ValueFloat > 0 {
+		labels = append(labels, "Projectile Momentum")
+		values = append(values, data.Momentum)
+	}
+	if len(data.LimitStatus) > 0 {
+		labels = append(labels, "Energy Limit", "Limit Velocity")
+		values = append(values, data.EnergyLimit, data.LimitVelocity)
+	}
+	if data.StoredEnergy.ValueFloat > 0 {
+		labels = append(labels, "Stored Energy", "Delivered Energy", "Efficiency")
+		values = append(values, data.StoredEnergy, data.DeliveredEnergy, data.Efficiency)
+	}
+	if data.RequiredSpine.ValueFloat > 0 {
+		labels = append(labels, "Required Spine")
+		values = append(values, data.RequiredSpine)
+	}
+	if data.NearZero.ValueFloat > 0 {
+		labels = append(labels, "Near Zero")
+		values = append(values, data.NearZero)
+	}
+	if data.FarZero.ValueFloat > 0 {
+		labels = append(labels, "Far Zero")
+		values = append(values, data.FarZero)
+	}
+	if data.ApexHeight.ValueFloat > 0 {
+		labels = append(labels, "Apex Height")
+		values = append(values, data.ApexHeight)
+	}
+	if data.PointBlankRange.ValueFloat > 0 {
+		labels = append(labels, "Point Blank Range")
+		values = append(values, data.PointBlankRange)
+	}
+	if data.RecommendedZero.ValueFloat > 0 {
+		labels = append(labels, "Recommended Zero")
+		values = append(values, data.RecommendedZero)
+	}
+	if data.Mpbr.ValueFloat > 0 {
+		labels = append(labels, "Max Point Blank Range")
+		values = append(values, data.Mpbr)
+	}
+	if data.ReleaseAngle.ValueFloat > 0 {
+		labels = append(labels, "Release Angle")
+		values = append(values, data.ReleaseAngle)
+	}
+	if data.Range.ValueFloat > 0 {
+		labels = append(labels, "Projectile Range")
+		values = append(values, data.Range)
+	}
+	if len(data.WindDrift.Label) > 0 {
+		labels = append(labels, "Wind Drift", "Wind Correction")
+		values = append(values, data.WindDrift, data.WindCorrection)
+	}
+
+	printLabeledValues(labels, values)
+
+	if len(data.LimitStatus) > 0 {
+		fmt.Println("")
+		fmt.Printf("%21s: %s\n", "Legal Limit", data.LimitStatus)
+	}
+
+	if len(data.SpineMatch) > 0 {
+		fmt.Println("")
+		fmt.Printf("%21s: %s\n", "Shaft Spine", data.SpineMatch)
+	}
+
+	if data.Game != nil {
+		fmt.Println("")
+		fmt.Printf("%21s: %s\n", "Energy Game Class", data.Game.Energy)
+		fmt.Printf("%21s: %s\n", "Momentum Game Class", data.Game.Momentum)
+	}
+
+	if data.Conditions != nil {
+		fmt.Println("")
+
+		labels = []string{"Temperature"}
+		values = []LabeledValue{data.Conditions.Temperature}
+		if data.Conditions.BarometricPressure != nil {
+			labels = append(labels, "Barometric Pressure")
+			values = append(values, *data.Conditions.BarometricPressure)
+		}
+		labels = append(labels, "Station Pressure", "Relative Humidity", "Altitude")
+		values = append(values, data.Conditions.Pressure, data.Conditions.Humidity, data.Conditions.Altitude)
+
+		printLabeledValues(labels, values)
+	}
+
+	for _, at := range data.At {
+		fmt.Println("")
+
+		labels = []string{"Distance", "Projectile Velocity", "Projectile Energy", "Projectile Momentum"}
+		values = []LabeledValue{at.Distance, at.Velocity, at.Energy, at.Momentum}
+
+		printLabeledValues(labels, values)
+	}
+
+	if len(data.Table) > 0 {
+		fmt.Println("")
+		outputTable(data.Table)
+	}
+
 	fmt.Println("")
 }
 
+
+/** Print the drop table (range card) with a column for each value right aligned */
+func outputTable(rows []TableRow) {
+	headers := []string{"Range", "Velocity", "Energy", "Momentum", "Drop", "Drift", "Time", "Elevation", "Windage"}
+	columns := func(row TableRow) []LabeledValue {
+		return []LabeledValue{row.Range, row.Velocity, row.Energy, row.Momentum, row.Drop, row.Drift, row.Time, row.Elevation, row.Windage}
+	}
+
+	units := make([]string, len(headers))
+	widths := make([]int, len(headers))
+	for i, value := range columns(rows[0]) {
+		units[i] = value.Label
+		if abbreviation, found := unit_abbreviations[value.Label]; found {
+			units[i] = abbreviation
+		}
+		widths[i] = maxInt(utf8.RuneCountInString(headers[i]), utf8.RuneCountInString(units[i]))
+	}
+
+	numbers := make([][]string, len(rows))
+	for r, row := range rows {
+		numbers[r] = make([]string, len(headers))
+		for i, value := range columns(row) {
+			numbers[r][i], _ = numberFormatter(value.ValueFloat)
+			widths[i] = maxInt(widths[i], utf8.RuneCountInString(numbers[r][i]))
+		}
+	}
+
+	printRow := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				fmt.Print("  ")
+			}
+			fmt.Printf("%*s", widths[i], cell)
+		}
+		fmt.Println("")
+	}
+
+	printRow(headers)
+	printRow(units)
+	for _, cells := range numbers {
+		printRow(cells)
+	}
+}
+
 // func numberFormat(number float64) (result string) {
 // 	// numberFormatBase(number)
 // 	str_float := fmt.Sprintf("%.6f", number)
@@ -475,6 +1490,7 @@ func outputJSON(data OutputData) {
 		fmt.Printf("data.Energy: %f %s\n", data.Energy.ValueFloat, data.Energy.Label)
 		fmt.Printf("data.Momentum: %f %s\n", data.Momentum.ValueFloat, data.Momentum.Label)
 		fmt.Printf("data.Mpbr: %f %s\n", data.Mpbr.ValueFloat, data.Mpbr.Label)
+		fmt.Printf("data.Range: %f %s\n", data.Range.ValueFloat, data.Range.Label)
 		fmt.Printf("data.Velocity: %f %s\n", data.Velocity.ValueFloat, data.Velocity.Label)
 	}
 	var err error
@@ -484,10 +1500,7 @@ func outputJSON(data OutputData) {
 	// data_obj := data
 	if output_debug {
 		fmt.Println("JSON data!")
-		fmt.Printf("data_obj[\"energy\"]: %f %s\n", data_obj["energy"].ValueFloat, data_obj["energy"].Label)
-		fmt.Printf("data_obj[\"momentum\"]: %f %s\n", data_obj["momentum"].ValueFloat, data_obj["momentum"].Label)
-		fmt.Printf("data_obj[\"mpbr\"]: %f %s\n", data_obj["mpbr"].ValueFloat, data_obj["mpbr"].Label)
-		fmt.Printf("data_obj[\"velocity\"]: %f %s\n", data_obj["velocity"].ValueFloat, data_obj["velocity"].Label)
+		fmt.Printf("data_obj: %v\n", data_obj)
 	}
 
 	if output_pretty {
@@ -514,38 +1527,257 @@ func outputJSON(data OutputData) {
 
 
 
-/** Convert velocity in mps to input units */
-func velocity_to_velocity(data BallisticData) (velocity LabeledValue) {
-	velocity.Label = ""
+/**
+ * Solve for the velocity needed to reach the projectile range through air
+ *
+ * The vacuum velocity is the lower bound as drag can only shorten the range.
+ * The drag is only known up to the last Mach number of the drag table so
+ * found is false if the projectile falls short of the range even that fast.
+ */
+func solveVelocityForRange(data BallisticData, vacuum_velocity float64) (velocity float64, found bool) {
+	reaches := func(velocity float64) bool {
+		data.projectile_velocity.Value = velocity
+		point, found := rangeInput(data).AtDrop(0.0)
+		return found && point.Distance >= data.projectile_range.Value
+	}
+
+	low := vacuum_velocity
+	high := data.drag_table[len(data.drag_table) - 1].Mach * data.atmosphere.SpeedOfSound()
+	if ! reaches(high) {
+		return 0.0, false
+	}
+
+	for i := 0; i < 48; i++ {
+		velocity = (low + high) * 0.5
+		if reaches(velocity) {
+			high = velocity
+		} else {
+			low = velocity
+		}
+	}
+
+	if output_debug {
+		log.Printf("solveVelocityForRange() <| vacuum velocity: %15.6f mps", vacuum_velocity)
+		log.Printf("solveVelocityForRange()  |        velocity: %15.6f mps", velocity)
+	}
+
+	return velocity, true
+}
+
+
+/**
+ * Build the trajectory solver input from the ballistic data
+ *
+ * The bore is at the projection angle if given, otherwise at the angle the
+ * sight is zeroed at.
+ */
+func trajectoryInput(data BallisticData) (input TrajectoryInput) {
+	if data.projection_angle.Value != 0 {
+		input.Angle = data.projection_angle.Value * ANGLE_DEGREES_TO_RADIANS
+	} else {
+		input.Angle = data.zero.Angle
+	}
+	input.Atmosphere = data.atmosphere
+	input.Mass = data.projectile_mass.Value
+	input.SightHeight = data.sight_height.Value
+	input.Velocity = data.projectile_velocity.Value
+	input.Winds = data.winds
+
+	if data.drag_table != nil && data.ballistic_coefficient.Value > 0 {
+		input.BallisticCoefficient = data.ballistic_coefficient.Value
+		input.Drag = data.drag_table.Coefficient
+	}
+
+	return input
+}
+
+
+/** Build the trajectory solver input for the range of the projectile from the muzzle to a horizontal plane */
+func rangeInput(data BallisticData) (input TrajectoryInput) {
+	input = trajectoryInput(data)
+	input.Angle = data.projection_angle.Value * ANGLE_DEGREES_TO_RADIANS
+	input.SightHeight = 0.0
+
+	return input
+}
+
+
+/** Calculate the trajectory zeros with the sight zeroed at the zero range */
+func calcZero(data BallisticData) (zero ZeroData, found bool) {
+	trajectory := trajectoryInput(data)
+	trajectory.Angle, found = trajectory.ZeroAngle(data.zero_range.Value)
+	if found {
+		zero = trajectory.Zero(data.target_radius.Value)
+	}
+
+	if output_debug {
+		log.Printf("calcZero() <|  zero range: %12.6f m", data.zero_range.Value)
+		log.Printf("calcZero()  |  bore angle: %12.6f rad", zero.Angle)
+		log.Printf("calcZero()  |   near zero: %12.6f m", zero.NearZero.Distance)
+		log.Printf("calcZero()  |    far zero: %12.6f m", zero.FarZero.Distance)
+		log.Printf("calcZero()  |        apex: %12.6f m", zero.Apex.Height)
+		log.Printf("calcZero()  | point blank: %12.6f m", zero.PointBlank.Distance)
+	}
+
+	return zero, found
+}
+
+
+/** Parse a comma separated list of distances ordered from nearest to farthest */
+func parseDistances(list string) (distances []ParsedData, err error) {
+	// Distances should not change the output between metric and imperial
+	metric := InputData.Metric
+	defer func() { InputData.Metric = metric }()
+
+	for _, distance := range strings.Split(list, ",") {
+		distance = strings.TrimSpace(distance)
+		if len(distance) > 0 {
+			parsed_data, err := ParsePositiveValue(distance, VALUE_TYPE_LENGTH)
+			if err != nil {
+				return nil, err
+			}
+			distances = append(distances, parsed_data)
+		}
+	}
+
+	sort.SliceStable(distances, func(i, j int) bool {
+		return distances[i].Value < distances[j].Value
+	})
+
+	return distances, nil
+}
+
+
+/** Parse the value of the flag naming the flag in the error if it is invalid */
+func parseFlag(c *cli.Context, flag_name, value_type string) (ParsedData, error) {
+	parsed_data, err := ParseValue(c.String(flag_name), value_type)
+	return parsed_data, flagError(err, flag_name)
+}
+
+
+/** Parse the value of the flag as parseFlag does requiring it to be greater than zero */
+func parsePositiveFlag(c *cli.Context, flag_name, value_type string) (ParsedData, error) {
+	parsed_data, err := ParsePositiveValue(c.String(flag_name), value_type)
+	return parsed_data, flagError(err, flag_name)
+}
+
+
+/** Parse the value of the flag as parseFlag does taking a number without units to be in the default units */
+func parseFlagDefault(c *cli.Context, flag_name, value_type, default_units string) (ParsedData, error) {
+	value := strings.TrimSpace(c.String(flag_name))
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		value += default_units
+	}
+
+	parsed_data, err := ParseValue(value, value_type)
+	return parsed_data, flagError(err, flag_name)
+}
+
+
+/** Parse the value of the flag as parseFlag does without the units changing the output between metric and imperial */
+func parseConditionFlag(c *cli.Context, flag_name, value_type string) (ParsedData, error) {
+	metric := InputData.Metric
+	defer func() { InputData.Metric = metric }()
+
+	return parseFlag(c, flag_name, value_type)
+}
+
+
+/** Parse the normalized mass of an arrow component flag taking a number without units to be in grains */
+func parseComponentFlag(c *cli.Context, flag_name string) (float64, error) {
+	parsed_data, err := parseFlagDefault(c, flag_name, VALUE_TYPE_MASS, "gr")
+	return parsed_data.Value, err
+}
+
+
+/** Parse the normalized value of the flag naming the flag in the error if it is invalid */
+func parseFlagValue(c *cli.Context, flag_name, value_type string) (float64, error) {
+	parsed_data, err := parseFlag(c, flag_name, value_type)
+	return parsed_data.Value, err
+}
+
+
+/** Returns the designation of the units given for the output units flag or an empty string if not given */
+func parseOutputUnits(c *cli.Context, flag_name, value_type string) (string, error) {
+	if len(c.String(flag_name)) == 0 {
+		return "", nil
+	}
+
+	unit, err := ParseUnits(c.String(flag_name), value_type)
+	if err != nil {
+		return "", flagError(err, flag_name)
+	}
+
+	return unit.Designation, nil
+}
+
+
+/** Name the flag the invalid value was given for in a ParseError */
+func flagError(err error, flag_name string) error {
+	if parse_err, ok := err.(*ParseError); ok {
+		parse_err.Flag = flag_name
+	}
+	return err
+}
+
+
+/** Returns the length units for the table ranges from the first table value given */
+func tableLengthLabel(data BallisticData) string {
+	for _, value := range []ParsedData{data.table_step, data.table_stop, data.table_start} {
+		if len(value.UserLabel) > 0 {
+			return value.UserLabel
+		}
+	}
 
-	user_label := InputData.Velocity
+	if len(output_range) > 0 {
+		return output_range
+	}
+	if outputImperial() {
+		return LENGTH_LABEL_YARD
+	}
+	return LENGTH_LABEL_METER
+}
+
+
+/** Convert temperature in kelvin to input units or units matching the output system */
+func temperature_to_temperature(kelvin float64) (temperature LabeledValue) {
+	var user_label string
+	if output_units == UNIT_SYSTEM_INPUT {
+		user_label = InputData.Temperature
+	}
 	if len(user_label) == 0 {
-		switch InputData.Mass {
-		case MASS_LABEL_GRAINS, MASS_LABEL_LONG_TON, MASS_LABEL_POUNDS, MASS_LABEL_SHORT_TON, MASS_LABEL_STONE:
-			user_label = VELOCITY_LABEL_FPS
-		default:
-			user_label = VELOCITY_LABEL_MPS
+		if outputMetric() {
+			user_label = TEMPERATURE_LABEL_CELSIUS
+		} else {
+			user_label = TEMPERATURE_LABEL_FAHRENHEIT
 		}
 	}
 
-	switch user_label {
-	case VELOCITY_LABEL_FPS:
-		velocity.Label = user_label
-		velocity.ValueFloat = data.projectile_velocity.Value * VELOCITY_FROM_MPS_TO_FPS
-	case VELOCITY_LABEL_KMPH:
-		velocity.Label = user_label
-		velocity.ValueFloat = data.projectile_velocity.Value * VELOCITY_FROM_MPS_TO_KMPH
-	case VELOCITY_LABEL_KNOTS:
-		velocity.Label = user_label
-		velocity.ValueFloat = data.projectile_velocity.Value * VELOCITY_FROM_MPS_TO_KNOTS
-	case VELOCITY_LABEL_MPS:
-		velocity.Label = user_label
-		velocity.ValueFloat = data.projectile_velocity.Value
-	case VELOCITY_LABEL_MPH:
-		velocity.Label = user_label
-		velocity.ValueFloat = data.projectile_velocity.Value * VELOCITY_FROM_MPS_TO_MPH
+	return quantity_to_label(Quantity{Dimension: VALUE_TYPE_TEMPERATURE, Value: kelvin}, user_label)
+}
+
+
+/** Convert velocity in mps to the --out-velocity units, units matching the output system or input units */
+func velocity_to_velocity(data BallisticData) (velocity LabeledValue) {
+	user_label := output_velocity
+	switch {
+	case len(user_label) > 0:
+	case output_units == UNIT_SYSTEM_METRIC:
+		user_label = VELOCITY_LABEL_MPS
+	case output_units == UNIT_SYSTEM_IMPERIAL:
+		user_label = VELOCITY_LABEL_FPS
+	default:
+		user_label = InputData.Velocity
+	}
+	if len(user_label) == 0 {
+		user_label = VELOCITY_LABEL_MPS
+		if mass_unit, _ := UnitByLabel(VALUE_TYPE_MASS, InputData.Mass); mass_unit.System == UNIT_SYSTEM_IMPERIAL {
+			user_label = VELOCITY_LABEL_FPS
+		}
 	}
 
+	velocity = quantity_to_label(Quantity{Dimension: VALUE_TYPE_VELOCITY, Value: data.projectile_velocity.Value}, user_label)
+
 	// log.Printf("velocity_to_velocity() | user_label: '%s'", user_label)
 	// log.Printf("velocity_to_velocity() | projectile_mass user value & label: %f %s", data.projectile_mass.UserValue, data.projectile_mass.UserLabel)
 	// log.Printf("velocity_to_velocity() | InputData | velocity: '%s' | mass: '%s' | metric: %t", InputData.Velocity, InputData.Mass, InputData.Metric)
@@ -557,6 +1789,28 @@ func velocity_to_velocity(data BallisticData) (velocity LabeledValue) {
 }
 
 
+/** Returns true if the bow type or draw curve were given to calculate arrow velocity from */
+func (data BallisticData) bowSet() bool {
+	curve := len(data.bow.Curve) > 0
+	bow_type := len(data.bow.Type) > 0 && (data.draw_length.Value > 0 || data.bow.Stroke > 0) && data.draw_force.Value > 0
+
+	return curve || bow_type
+}
+
+
+/** Returns true if atmospheric conditions were given or affect the drag on the projectile */
+func (data BallisticData) conditionsSet() bool {
+	drag := data.drag_table != nil && data.ballistic_coefficient.Value > 0
+	measured := len(data.altitude.UserLabel) > 0 ||
+		len(data.barometric_pressure.UserLabel) > 0 ||
+		len(data.humidity.UserLabel) > 0 ||
+		len(data.station_pressure.UserLabel) > 0 ||
+		len(data.temperature.UserLabel) > 0
+
+	return drag || measured
+}
+
+
 //
 // MAIN ENTRYPOINT
 //
@@ -569,44 +1823,214 @@ func main() {
 	app.Version = APP_VERSION
 
 	app.Flags = []cli.Flag {
+		cli.StringFlag{
+			Name: "altitude, elevation",
+			Usage: "The `ALTITUDE` above sea level. Used for the standard atmosphere when temperature or pressure are not given.",
+		},
 		cli.StringFlag{
 			Name: "projection-angle, angle, a",
 			Usage: "The projection angle or trajectory of projectile",
 		},
+		cli.StringFlag{
+			Name: "at",
+			Usage: "The `DISTANCES` to calculate remaining velocity, energy and momentum at, separated by commas. i.e. 100yd,200yd,300yd",
+		},
+		cli.StringFlag{
+			Name: "barometric-pressure, baro",
+			Usage: "The barometric `PRESSURE` (corrected to sea level) as given by weather reports. Used to calculate air density.",
+		},
+		cli.StringFlag{
+			Name: "arrow-length",
+			Usage: "The arrow `LENGTH` from the nock throat to the end of the shaft. Used with the shaft weight and components to calculate arrow mass and FOC.",
+		},
+		cli.StringFlag{
+			Name: "balance-point",
+			Usage: "The arrow balance `POINT` measured from the nock throat. Used to calculate FOC. Estimated from the components if not given.",
+		},
+		cli.StringFlag{
+			Name: "ballistic-coefficient, bc",
+			Usage: "The projectile `BC` (ballistic coefficient) for the drag model. Used to calculate drag on the projectile in flight.",
+		},
 		cli.BoolFlag{
 			Name: "debug, D",
 			Usage: "Output debug info",
 		},
+		cli.StringFlag{
+			Name: "click",
+			Usage: "The scope turret `CLICK` value. i.e. 0.25moa or 0.1mrad. Corrections are output in clicks when given.",
+		},
+		cli.StringFlag{
+			Name: "correction-units, corrections",
+			Usage: "The angle `UNITS` for corrections. One of moa, smoa, mrad or mil. Defaults to moa for imperial and mrad for metric output.",
+		},
+		cli.StringFlag{
+			Name: "band-length",
+			Usage: "The slingshot band `LENGTH` from the fork to the pouch at rest. (default: 180mm)",
+		},
+		cli.StringFlag{
+			Name: "band-taper",
+			Usage: "The slingshot band `TAPER` as the pouch end width percentage of the fork end width. (default: 80%)",
+		},
+		cli.StringFlag{
+			Name: "band-thickness",
+			Usage: "The slingshot band `THICKNESS`. (default: 0.7mm)",
+		},
+		cli.StringFlag{
+			Name: "band-width",
+			Usage: "The slingshot band `WIDTH` at the fork end. (default: 25mm)",
+		},
+		cli.StringFlag{
+			Name: "bow",
+			Usage: "The bow `TYPE`. One of longbow, recurve, compound or crossbow. Used with the draw weight and length to calculate arrow velocity.",
+		},
+		cli.StringFlag{
+			Name: "brace-height",
+			Usage: "The bow `BRACE` height from the grip pivot to the string. Defaults to a typical bow of the type.",
+		},
+		cli.StringFlag{
+			Name: "cam",
+			Usage: "The compound bow or crossbow cam `PROFILE`. One of soft, medium or hard. (default: medium)",
+		},
+		cli.StringFlag{
+			Name: "counterweight",
+			Usage: "The trebuchet counterweight `MASS`. Used with the arm lengths and drop height to calculate stored energy.",
+		},
 		cli.StringFlag{
 			Name: "projectile-range, distance, d",
 			Usage: "The distance the projectile traveled",
 		},
+		cli.StringFlag{
+			Name: "caliber, diameter",
+			Usage: "The projectile `CALIBER` (diameter), in inches if given without units, or with a pellet shape a nominal air gun caliber i.e. .177 or .22. Used with a drag file or pellet shape to calculate sectional density.",
+		},
+		cli.StringFlag{
+			Name: "drag-file",
+			Usage: "A CSV or JSON `FILE` of Mach number and drag coefficient pairs measured for the projectile. Used in place of the drag model.",
+		},
+		cli.StringFlag{
+			Name: "drag-model, drag",
+			Value: DRAG_MODEL_DEFAULT,
+			Usage: "The standard drag `MODEL` the ballistic coefficient references. One of G1, G2, G5, G6, G7, G8, GA, GL, GS or RA4.",
+		},
+		cli.StringFlag{
+			Name: "draw-curve",
+			Usage: "A CSV `FILE` of draw length and draw force pairs measured for the bow. Used in place of the draw weight to calculate stored energy.",
+		},
 		cli.StringFlag{
 			Name: "draw-weight, weight, w",
-			Usage: "Bow or sling shot draw `WEIGHT`. Used to calculate projectile velocity, energy, etc.",
+			Usage: "Bow or catapult draw `WEIGHT`. Used to calculate projectile velocity, energy, etc.",
 		},
 		cli.StringFlag{
 			Name: "draw-length, length, l",
-			Usage: "Bow or sling shot draw `LENGTH`. Used to calculate projectile velocity, energy, etc.",
+			Usage: "Bow or catapult draw or slingshot pull `LENGTH`. Used to calculate projectile velocity, energy, etc.",
+		},
+		cli.StringFlag{
+			Name: "drop-height, drop",
+			Usage: "The `HEIGHT` the trebuchet counterweight falls to release. (default: 1.71 times the short arm)",
 		},
 		// cli.BoolFlag{
 		// 	Name: "help, h",
 		// 	Usage: "Output this help info",
 		// },
+		cli.StringFlag{
+			Name: "efficiency",
+			Usage: "The bow or siege engine `EFFICIENCY` percentage of stored energy delivered to the projectile. Used in place of the virtual mass. A siege engine efficiency given is used as is, without the trebuchet arm limit.",
+		},
+		cli.StringFlag{
+			Name: "energy",
+			Usage: "The projectile kinetic `ENERGY`. Used with mass, velocity or momentum to calculate the others.",
+		},
+		cli.StringFlag{
+			Name: "fletches",
+			Value: "3",
+			Usage: "The `NUMBER` of vanes or feathers on the arrow.",
+		},
+		cli.StringFlag{
+			Name: "fletching",
+			Usage: "The `MASS` of each vane or feather on the arrow, in grains if given without units.",
+		},
+		cli.StringFlag{
+			Name: "gpi, shaft-weight",
+			Usage: "The arrow shaft `WEIGHT` per length in grains per inch (GPI). Used with the arrow length and components to calculate arrow mass and FOC.",
+		},
+		cli.StringFlag{
+			Name: "energy-limit, limit",
+			Usage: "The legal muzzle energy `LIMIT` as an energy or one of uk (12 ft-lbf), uk-pistol (6 ft-lbf) or germany (7.5 J). The projectile energy is flagged if it exceeds the limit.",
+		},
+		cli.StringFlag{
+			Name: "humidity, rh",
+			Usage: "The relative `HUMIDITY` of the air. Used to calculate air density.",
+		},
+		cli.StringFlag{
+			Name: "insert",
+			Usage: "The `MASS` of the arrow insert, in grains if given without units.",
+		},
+		cli.StringFlag{
+			Name: "hysteresis",
+			Usage: "The slingshot band `HYSTERESIS` percentage of stored energy lost as heat on release. Defaults to a typical band of the material.",
+		},
+		cli.StringFlag{
+			Name: "ibo, ata",
+			Usage: "The compound bow IBO/ATA `SPEED` rating (70lb, 30in, 350gr) in fps if given without units. Adjusted for the draw weight, draw length, arrow mass and string extras to estimate arrow velocity.",
+		},
 		cli.BoolFlag{
 			Name: "json, j",
 			Usage: "Output JSON data",
 		},
 		cli.StringFlag{
-			Name: "locale, local",
-			Value: "en_US",
-			Usage: "The `LOCALE` to format number output for.",
-			EnvVar: "LC_CTYPE,LANG",
+			Name: "long-arm, arm",
+			Usage: "The siege engine throwing arm `LENGTH` from the pivot. Used with the short arm and sling to calculate velocity and release height.",
+		},
+		cli.StringFlag{
+			Name: "let-off",
+			Usage: "The compound bow or crossbow `LETOFF` percentage of peak draw weight at full draw. (default: 80% for compound bows and none for crossbows)",
+		},
+		cli.StringFlag{
+			Name: "locale, local",
+			Value: "en_US",
+			Usage: "The `LOCALE` to format number output for.",
+			EnvVar: "LC_CTYPE,LANG",
+		},
+		cli.StringFlag{
+			Name: "momentum",
+			Usage: "The projectile `MOMENTUM`. Used with mass, velocity or energy to calculate the others.",
+		},
+		cli.StringFlag{
+			Name: "nock",
+			Usage: "The `MASS` of the arrow nock, in grains if given without units.",
+		},
+		cli.StringFlag{
+			Name: "out-energy",
+			Usage: "The `UNITS` to output energy in. i.e. J or ft-lb. Defaults to units matching the output system.",
+		},
+		cli.StringFlag{
+			Name: "out-momentum",
+			Usage: "The `UNITS` to output momentum in. i.e. Ns or lb-ft/s. Defaults to units matching the output system.",
+		},
+		cli.StringFlag{
+			Name: "out-range",
+			Usage: "The `UNITS` to output ranges and zeros in. i.e. m, yd or ft. Defaults to the units of the zero range or distance given, or units matching the output velocity.",
+		},
+		cli.StringFlag{
+			Name: "out-velocity",
+			Usage: "The `UNITS` to output velocity in. i.e. fps or m/s. Defaults to the input velocity units.",
+		},
+		cli.StringFlag{
+			Name: "pellet",
+			Usage: "The air gun pellet `SHAPE`. One of domed, pointed, hollow-point or wadcutter. Used with the caliber and mass for the BC against the GA drag model.",
+		},
+		cli.StringFlag{
+			Name: "point",
+			Usage: "The `MASS` of the arrow point, field tip or broadhead, in grains if given without units.",
 		},
 		cli.StringFlag{
 			Name: "projectile, mass, m",
 			Usage: "Projectile `MASS` (weight). Used to calculate projectile velocity, energy, etc.",
 		},
+		cli.StringFlag{
+			Name: "power-stroke, stroke",
+			Usage: "The bow or crossbow power `STROKE` the string pushes the arrow or bolt. Used in place of the draw length and brace height.",
+		},
 		cli.StringFlag{
 			Name: "precision, float, f",
 			Value: "6",
@@ -624,15 +2048,101 @@ func main() {
 		// 	Name: "pretty-print",
 		// 	Usage: "Pretty printed JSON output specifying `INDENT` string",
 		// },
+		cli.StringFlag{
+			Name: "pressure, station-pressure",
+			Usage: "The station (absolute) `PRESSURE` at the shooting location. Used to calculate air density.",
+		},
 		cli.StringFlag{
 			Name: "radius, r",
 			Value: "225mm",
 			Usage: "The `RADIUS` of the target area. Used to calculate MPBR (Maximum Point Blank Range).",
 		},
+		cli.StringFlag{
+			Name: "revolutions, rps",
+			Usage: "The sling `RATE` of revolutions per second (or rpm) at release. Used with the sling cord length to calculate stone velocity.",
+		},
+		cli.StringFlag{
+			Name: "release-height",
+			Usage: "The `HEIGHT` above the ground the siege engine releases the projectile. (default: estimated from the long arm)",
+		},
+		cli.StringFlag{
+			Name: "short-arm",
+			Usage: "The trebuchet counterweight arm `LENGTH` from the pivot. Used with the long arm and sling to calculate velocity.",
+		},
+		cli.StringFlag{
+			Name: "siege",
+			Usage: "The siege engine `TYPE`. One of trebuchet, torsion or tension. Used to calculate projectile velocity, release angle and range.",
+		},
+		cli.StringFlag{
+			Name: "sight-height",
+			Usage: "The `HEIGHT` of the sight line above the center of the bore. Used to calculate zeros and MPBR.",
+		},
+		cli.BoolFlag{
+			Name: "table",
+			Usage: "Output a drop table (range card) of velocity, energy, momentum, drop, drift, time of flight and corrections by range",
+		},
+		cli.StringFlag{
+			Name: "sling",
+			Usage: "The sling cord `LENGTH` from the hand or trebuchet arm to the pouch. Used with the revolutions to calculate stone velocity.",
+		},
+		cli.StringFlag{
+			Name: "slingshot",
+			Usage: "The slingshot band `MATERIAL`. One of latex, gum or tpe. Used with the band sizes and pull length to calculate ammo velocity.",
+		},
+		cli.StringFlag{
+			Name: "spine",
+			Usage: "The arrow shaft static `SPINE` deflection i.e. 340 or 0.340. Compared to the spine required for the bow and arrow.",
+		},
+		cli.StringFlag{
+			Name: "string-extras",
+			Usage: "The `MASS` of peep sights, D-loops, silencers, etc. on the string. Used with the IBO speed rating.",
+		},
+		cli.StringFlag{
+			Name: "table-start",
+			Usage: "The `RANGE` the table starts at. (default: 0)",
+		},
+		cli.StringFlag{
+			Name: "table-step",
+			Usage: "The `RANGE` between table rows. (default: 100yd or 100m)",
+		},
+		cli.StringFlag{
+			Name: "table-stop",
+			Usage: "The `RANGE` the table stops at. (default: 1000yd or 1000m)",
+		},
+		cli.StringFlag{
+			Name: "temperature, temp, t",
+			Usage: "The air `TEMPERATURE`. Used to calculate air density and the speed of sound.",
+		},
+		cli.StringFlag{
+			Name: "units",
+			Value: UNIT_SYSTEM_INPUT,
+			Usage: "The `SYSTEM` of units to output. One of metric, imperial or input to match the units of the input values.",
+			EnvVar: "BALLISTIC_UNITS",
+		},
+		cli.StringFlag{
+			Name: "virtual-mass",
+			Usage: "The bow virtual `MASS` of the limbs and string moving with the arrow. Used in place of the efficiency. Defaults to a typical bow of the type.",
+		},
 		cli.StringFlag{
 			Name: "velocity, v",
 			Usage: "The projectile `VELOCITY` (speed). Used to calculate projectile energy, momentum, etc.",
 		},
+		cli.StringFlag{
+			Name: "wind",
+			Usage: "Wind `ZONES` as SPEED@DIRECTION:UNTIL separated by commas. i.e. 10mph@3oclock:300yd,5mph@10oclock",
+		},
+		cli.StringFlag{
+			Name: "wind-direction",
+			Usage: "The `DIRECTION` the wind blows from as an angle or clock face direction. Defaults to full value from 3 o'clock.",
+		},
+		cli.StringFlag{
+			Name: "wind-speed",
+			Usage: "The wind `SPEED`. Used to calculate wind drift.",
+		},
+		cli.StringFlag{
+			Name: "zero-range, zero",
+			Usage: "The `DISTANCE` the sight is zeroed at. Defaults to the recommended zero for MPBR.",
+		},
 	}
 
 	cli.HelpFlag = cli.BoolFlag{
@@ -640,7 +2150,7 @@ func main() {
 		Usage: "Output this help info",
 	}
 
-	cli.AppHelpTemplate = fmt.Sprintf(HELP_TEMPLATE)
+	cli.AppHelpTemplate = HELP_TEMPLATE
 
 	cli.VersionFlag = cli.BoolFlag{
 		Name: "version, V",
@@ -650,10 +2160,11 @@ func main() {
 	sort.Sort(cli.FlagsByName(app.Flags))
 	// sort.Sort(cli.CommandsByName(app.Commands))
 
-	app.Action = func(c *cli.Context) error {
+	app.Action = func(c *cli.Context) (err error) {
 		output_debug = c.Bool("debug")
 		output_json = c.Bool("json")
 		output_pretty = c.Bool("pretty-print")
+		output_table = c.Bool("table")
 		decimal_places = c.Int("precision")
 		locale_str = c.String("locale")
 
@@ -675,6 +2186,10 @@ func main() {
 			log.Printf("      target radius: %12s (%d)", c.String("radius"), len(c.String("radius")))
 			log.Printf("projectile velocity: %12s (%d)", c.String("velocity"), len(c.String("velocity")))
 			log.Printf("    projectile mass: %12s (%d)", c.String("projectile"), len(c.String("projectile")))
+			log.Printf("ballistic coefficient: %10s (%d)", c.String("ballistic-coefficient"), len(c.String("ballistic-coefficient")))
+			log.Printf("         drag model: %12s (%d)", c.String("drag-model"), len(c.String("drag-model")))
+			log.Printf("          drag file: %12s (%d)", c.String("drag-file"), len(c.String("drag-file")))
+			log.Printf("            caliber: %12s (%d)", c.String("caliber"), len(c.String("caliber")))
 			log.Printf("          precision: %12s (%d)", c.String("precision"), len(c.String("precision")))
 			// log.Println("")
 			// fmt.Println("")
@@ -685,7 +2200,7 @@ func main() {
 		for _, flag_name := range c.GlobalFlagNames() {
 			// fmt.Printf("Flag: %s\n", flag_name)
 			switch flag_name {
-			case "locale", "precision", "radius":
+			case "drag-model", "fletches", "locale", "precision", "radius", "units":
 			default:
 				flag_value := c.String(flag_name)
 				if len(flag_value) > 0 {
@@ -705,38 +2220,630 @@ func main() {
 
 
 		if len(c.String("draw-length")) > 0 {
-			data.draw_length = ParseValue(c.String("draw-length"), VALUE_TYPE_LENGTH)
+			if data.draw_length, err = parseFlag(c, "draw-length", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
 		}
 		if len(c.String("draw-weight")) > 0 {
-			data.draw_weight = ParseValue(c.String("draw-weight"), VALUE_TYPE_MASS)
+			if data.draw_weight, err = parseFlag(c, "draw-weight", VALUE_TYPE_MASS); err != nil {
+				return err
+			}
 			avg_draw_weight := data.draw_weight.Value * 0.5
 			data.draw_force = calcForce(avg_draw_weight)
 		}
+		if len(c.String("bow")) > 0 {
+			bow, found := BowModel(c.String("bow"))
+			if ! found {
+				return fmt.Errorf("Unknown bow type %q. Expected one of: %s", c.String("bow"), strings.Join(BowModelNames(), ", "))
+			}
+			if len(c.String("brace-height")) > 0 {
+				if bow.BraceHeight, err = parseFlagValue(c, "brace-height", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+			}
+			if len(c.String("let-off")) > 0 {
+				if bow.Type != BOW_TYPE_COMPOUND && bow.Type != BOW_TYPE_CROSSBOW {
+					return fmt.Errorf("The let-off only applies to compound bows and crossbows")
+				}
+				if bow.LetOff, err = parseFlagValue(c, "let-off", VALUE_TYPE_PERCENT); err != nil {
+					return err
+				}
+			}
+			if len(c.String("power-stroke")) > 0 {
+				if bow.Stroke, err = parseFlagValue(c, "power-stroke", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+				if bow.Stroke <= 0 {
+					return fmt.Errorf("The power stroke must be greater than zero")
+				}
+			}
+			if len(c.String("cam")) > 0 {
+				bow.Cam, found = BowCamProfile(c.String("cam"))
+				if ! found {
+					return fmt.Errorf("Unknown cam profile %q. Expected one of: soft, medium or hard", c.String("cam"))
+				}
+			}
+			data.bow = bow
+		} else if len(c.String("power-stroke")) > 0 {
+			return fmt.Errorf("The power stroke requires the bow type")
+		}
+		data.bow.DrawLength = data.draw_length.Value
+		data.bow.DrawWeight = data.draw_weight.Value * FORCE_FROM_KILOGRAMS_TO_NEWTONS
+
+		if len(c.String("draw-curve")) > 0 {
+			curve, err := LoadDrawCurve(c.String("draw-curve"))
+			if err != nil {
+				return err
+			}
+			data.bow.Curve = curve
+		}
+		if len(c.String("efficiency")) > 0 && len(c.String("siege")) > 0 {
+			if len(c.String("virtual-mass")) > 0 {
+				return fmt.Errorf("The virtual mass does not apply to siege engines. Give the efficiency instead")
+			}
+		} else if len(c.String("efficiency")) > 0 || len(c.String("virtual-mass")) > 0 {
+			if len(data.bow.Type) == 0 && len(data.bow.Curve) == 0 {
+				return fmt.Errorf("The bow efficiency and virtual mass require a bow type or draw curve")
+			}
+			if len(c.String("efficiency")) > 0 && len(c.String("virtual-mass")) > 0 {
+				return fmt.Errorf("Give either the bow efficiency or the virtual mass, not both")
+			}
+		}
+		if len(c.String("efficiency")) > 0 {
+			if data.bow.Efficiency, err = parseFlagValue(c, "efficiency", VALUE_TYPE_PERCENT); err != nil {
+				return err
+			}
+		}
+		if len(c.String("virtual-mass")) > 0 {
+			if data.bow.VirtualMass, err = parseFlagValue(c, "virtual-mass", VALUE_TYPE_MASS); err != nil {
+				return err
+			}
+		}
+		if len(c.String("ibo")) > 0 {
+			if len(data.bow.Type) > 0 || len(data.bow.Curve) > 0 {
+				return fmt.Errorf("Give either the IBO speed rating or the bow type and draw curve, not both")
+			}
+			// Speed ratings are quoted in feet per second
+			speed_rating, err := parseFlagDefault(c, "ibo", VALUE_TYPE_VELOCITY, "fps")
+			if err != nil {
+				return err
+			}
+			data.bow.SpeedRating = speed_rating.Value
+			if data.bow.SpeedRating <= 0 {
+				return fmt.Errorf("The IBO speed rating must be greater than zero")
+			}
+		}
+		if len(c.String("string-extras")) > 0 {
+			if data.bow.SpeedRating == 0 {
+				return fmt.Errorf("The string extras require the IBO speed rating")
+			}
+			if data.bow.StringMass, err = parseFlagValue(c, "string-extras", VALUE_TYPE_MASS); err != nil {
+				return err
+			}
+		}
+		if len(c.String("slingshot")) > 0 {
+			if len(data.bow.Type) > 0 || len(data.bow.Curve) > 0 || data.bow.SpeedRating > 0 {
+				return fmt.Errorf("Give either the bow or the slingshot, not both")
+			}
+			if data.draw_weight.Value > 0 {
+				return fmt.Errorf("The slingshot draw weight comes from the bands. Give the band sizes instead of the draw weight")
+			}
+			slingshot, found := SlingshotModel(c.String("slingshot"))
+			if ! found {
+				return fmt.Errorf("Unknown band material %q. Expected one of: %s", c.String("slingshot"), strings.Join(BandMaterialNames(), ", "))
+			}
+			if len(c.String("band-length")) > 0 {
+				if slingshot.Length, err = parseFlagValue(c, "band-length", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+			}
+			if len(c.String("band-taper")) > 0 {
+				if slingshot.Taper, err = parseFlagValue(c, "band-taper", VALUE_TYPE_PERCENT); err != nil {
+					return err
+				}
+			}
+			if len(c.String("band-thickness")) > 0 {
+				if slingshot.Thickness, err = parseFlagValue(c, "band-thickness", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+			}
+			if len(c.String("band-width")) > 0 {
+				if slingshot.Width, err = parseFlagValue(c, "band-width", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+			}
+			if len(c.String("hysteresis")) > 0 {
+				if slingshot.Hysteresis, err = parseFlagValue(c, "hysteresis", VALUE_TYPE_PERCENT); err != nil {
+					return err
+				}
+			}
+			slingshot.DrawLength = data.draw_length.Value
+
+			if slingshot.Taper <= 0 || slingshot.Taper > 1 {
+				return fmt.Errorf("The band taper must be greater than 0%% and no more than 100%%")
+			}
+			if slingshot.Hysteresis < 0 || slingshot.Hysteresis >= 1 {
+				return fmt.Errorf("The band hysteresis must be at least 0%% and less than 100%%")
+			}
+			if slingshot.DrawLength == 0 {
+				return fmt.Errorf("The slingshot requires the draw length")
+			}
+			if slingshot.Stretch() <= 0 {
+				return fmt.Errorf("The draw length must be longer than the band length")
+			}
+			data.slingshot = slingshot
+		} else if len(c.String("band-length")) > 0 || len(c.String("band-taper")) > 0 || len(c.String("band-thickness")) > 0 || len(c.String("band-width")) > 0 || len(c.String("hysteresis")) > 0 {
+			return fmt.Errorf("The band sizes and hysteresis require the slingshot band material")
+		}
+		if len(c.String("siege")) > 0 {
+			if len(data.bow.Type) > 0 || len(data.bow.Curve) > 0 || data.bow.SpeedRating > 0 || data.slingshot.Bands > 0 {
+				return fmt.Errorf("Give either the siege engine or the bow or slingshot, not both")
+			}
+			engine, found := SiegeEngineModel(c.String("siege"))
+			if ! found {
+				return fmt.Errorf("Unknown siege engine %q. Expected one of: %s", c.String("siege"), strings.Join(SiegeEngineNames(), ", "))
+			}
+			if len(c.String("efficiency")) > 0 {
+				if engine.Efficiency, err = parseFlagValue(c, "efficiency", VALUE_TYPE_PERCENT); err != nil {
+					return err
+				}
+				engine.Delivered = true
+			}
+			if len(c.String("long-arm")) > 0 {
+				if engine.LongArm, err = parseFlagValue(c, "long-arm", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+			}
+			if len(c.String("release-height")) > 0 {
+				if engine.ReleaseHeight, err = parseFlagValue(c, "release-height", VALUE_TYPE_LENGTH); err != nil {
+					return err
+				}
+			}
+
+			if engine.Type == SIEGE_TYPE_TREBUCHET {
+				if len(c.String("counterweight")) > 0 {
+					if engine.Counterweight, err = parseFlagValue(c, "counterweight", VALUE_TYPE_MASS); err != nil {
+						return err
+					}
+				}
+				if len(c.String("drop-height")) > 0 {
+					if engine.Drop, err = parseFlagValue(c, "drop-height", VALUE_TYPE_LENGTH); err != nil {
+						return err
+					}
+				}
+				if len(c.String("short-arm")) > 0 {
+					if engine.ShortArm, err = parseFlagValue(c, "short-arm", VALUE_TYPE_LENGTH); err != nil {
+						return err
+					}
+				}
+				if len(c.String("sling")) > 0 {
+					if engine.Sling, err = parseFlagValue(c, "sling", VALUE_TYPE_LENGTH); err != nil {
+						return err
+					}
+				}
+				if data.draw_weight.Value > 0 {
+					return fmt.Errorf("The trebuchet stored energy comes from the counterweight. Give the counterweight instead of the draw weight")
+				}
+				if engine.Counterweight <= 0 || engine.LongArm <= 0 || engine.ShortArm <= 0 {
+					return fmt.Errorf("The trebuchet requires the counterweight, long arm and short arm")
+				}
+			} else {
+				if len(c.String("counterweight")) > 0 || len(c.String("drop-height")) > 0 || len(c.String("short-arm")) > 0 || len(c.String("sling")) > 0 {
+					return fmt.Errorf("The counterweight, drop height, short arm and sling only apply to trebuchets")
+				}
+				engine.DrawLength = data.draw_length.Value
+				engine.DrawWeight = data.bow.DrawWeight
+				if engine.DrawLength <= 0 || engine.DrawWeight <= 0 {
+					return fmt.Errorf("The %s catapult requires the draw weight and draw length", engine.Type)
+				}
+			}
+
+			if engine.Efficiency <= 0 || engine.Efficiency > 1 {
+				return fmt.Errorf("The efficiency must be greater than 0%% and no more than 100%%")
+			}
+			data.siege = engine
+		} else if len(c.String("counterweight")) > 0 || len(c.String("drop-height")) > 0 || len(c.String("long-arm")) > 0 || len(c.String("short-arm")) > 0 || len(c.String("release-height")) > 0 {
+			return fmt.Errorf("The arm lengths, counterweight, drop and release heights require the siege engine type")
+		} else if len(c.String("sling")) > 0 {
+			if len(data.bow.Type) > 0 || len(data.bow.Curve) > 0 || data.bow.SpeedRating > 0 || data.slingshot.Bands > 0 {
+				return fmt.Errorf("Give either the sling or the bow or slingshot, not both")
+			}
+			if data.sling.Length, err = parseFlagValue(c, "sling", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+			if len(c.String("revolutions")) > 0 {
+				if data.sling.Revolutions, err = parseFlagValue(c, "revolutions", VALUE_TYPE_FREQUENCY); err != nil {
+					return err
+				}
+			}
+			if data.sling.Length <= 0 || data.sling.Revolutions <= 0 {
+				return fmt.Errorf("The sling requires the cord length and revolutions per second")
+			}
+		}
+		if len(data.bow.Type) > 0 && len(data.bow.Curve) == 0 && data.draw_length.Value > 0 && data.bow.PowerStroke() <= 0 {
+			return fmt.Errorf("The draw length must be longer than the brace height")
+		}
 		if len(c.String("velocity")) > 0 {
-			data.projectile_velocity = ParseValue(c.String("velocity"), VALUE_TYPE_VELOCITY)
+			if data.projectile_velocity, err = parsePositiveFlag(c, "velocity", VALUE_TYPE_VELOCITY); err != nil {
+				return err
+			}
 		}
 		if len(c.String("mass")) > 0 {
-			data.projectile_mass = ParseValue(c.String("mass"), VALUE_TYPE_MASS)
+			if data.projectile_mass, err = parsePositiveFlag(c, "mass", VALUE_TYPE_MASS); err != nil {
+				return err
+			}
+		}
+		if len(c.String("arrow-length")) > 0 || len(c.String("gpi")) > 0 || len(c.String("point")) > 0 || len(c.String("insert")) > 0 || len(c.String("nock")) > 0 || len(c.String("fletching")) > 0 || len(c.String("balance-point")) > 0 {
+			if data.projectile_mass.Value > 0 {
+				return fmt.Errorf("Give either the arrow mass or the arrow components, not both")
+			}
+			fletches, err := strconv.Atoi(strings.TrimSpace(c.String("fletches")))
+			if err != nil || fletches < 0 {
+				return fmt.Errorf("The number of fletches must be a whole number, not %q", c.String("fletches"))
+			}
+			data.arrow = Arrow{Fletches: fletches}
+			if data.arrow.BalancePoint, err = parseFlagValue(c, "balance-point", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+			if data.arrow.Fletching, err = parseComponentFlag(c, "fletching"); err != nil {
+				return err
+			}
+			if data.arrow.Insert, err = parseComponentFlag(c, "insert"); err != nil {
+				return err
+			}
+			if data.arrow.Length, err = parseFlagValue(c, "arrow-length", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+			if data.arrow.Nock, err = parseComponentFlag(c, "nock"); err != nil {
+				return err
+			}
+			if data.arrow.Point, err = parseComponentFlag(c, "point"); err != nil {
+				return err
+			}
+			if data.arrow.Shaft, err = parseFlagValue(c, "gpi", VALUE_TYPE_LINEAR_DENSITY); err != nil {
+				return err
+			}
+			if err := data.arrow.Validate(); err != nil {
+				return err
+			}
+			data.projectile_mass.Value = data.arrow.Mass()
+			data.projectile_mass.Label = "kilogram"
+		}
+		if len(c.String("spine")) > 0 {
+			if data.spine, err = strconv.ParseFloat(strings.TrimSpace(c.String("spine")), 64); err != nil {
+				return fmt.Errorf("The shaft spine must be a number i.e. 340 or 0.340, not %q", c.String("spine"))
+			}
+			if data.spine > 0 && data.spine < 1 {
+				data.spine *= 1000
+			}
+			if data.spine <= 0 {
+				return fmt.Errorf("The shaft spine must be greater than zero")
+			}
+			if data.bow.Type == BOW_TYPE_CROSSBOW {
+				return fmt.Errorf("The shaft spine does not apply to crossbow bolts")
+			}
+			if data.bow.DrawWeight == 0 && len(data.bow.Curve) == 0 {
+				return fmt.Errorf("The shaft spine requires the draw weight or draw curve")
+			}
+			if data.arrow.Length == 0 && data.bow.DrawLength == 0 {
+				return fmt.Errorf("The shaft spine requires the arrow length or draw length")
+			}
+		}
+		if len(c.String("energy")) > 0 {
+			if data.projectile_energy, err = parseFlag(c, "energy", VALUE_TYPE_ENERGY); err != nil {
+				return err
+			}
+		}
+		if len(c.String("momentum")) > 0 {
+			if data.projectile_momentum, err = parseFlag(c, "momentum", VALUE_TYPE_MOMENTUM); err != nil {
+				return err
+			}
+		}
+		given := 0
+		for _, value := range []ParsedData{data.projectile_mass, data.projectile_velocity, data.projectile_energy, data.projectile_momentum} {
+			if value.Value != 0 {
+				given += 1
+			}
+		}
+		if given > 2 {
+			return fmt.Errorf("Give at most two of the mass, velocity, energy and momentum. The others are calculated from them")
 		}
 		if len(c.String("projectile-range")) > 0 {
-			data.projectile_range = ParseValue(c.String("projectile-range"), VALUE_TYPE_LENGTH)
+			if data.projectile_range, err = parsePositiveFlag(c, "projectile-range", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
 		}
 		if len(c.String("projection-angle")) > 0 {
-			data.projection_angle = ParseValue(c.String("projection-angle"), VALUE_TYPE_MASS)
+			if data.projection_angle, err = parseFlag(c, "projection-angle", VALUE_TYPE_ANGLE); err != nil {
+				return err
+			}
+		}
+		if len(c.String("at")) > 0 {
+			if data.at, err = parseDistances(c.String("at")); err != nil {
+				return flagError(err, "at")
+			}
+		}
+		// The shooting conditions should not change the output between metric and imperial
+		if len(c.String("altitude")) > 0 {
+			if data.altitude, err = parseConditionFlag(c, "altitude", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+		}
+		if len(c.String("barometric-pressure")) > 0 {
+			if data.barometric_pressure, err = parseConditionFlag(c, "barometric-pressure", VALUE_TYPE_PRESSURE); err != nil {
+				return err
+			}
+		}
+		if len(c.String("humidity")) > 0 {
+			if data.humidity, err = parseConditionFlag(c, "humidity", VALUE_TYPE_PERCENT); err != nil {
+				return err
+			}
+		}
+		if len(c.String("pressure")) > 0 {
+			if data.station_pressure, err = parseConditionFlag(c, "pressure", VALUE_TYPE_PRESSURE); err != nil {
+				return err
+			}
+		}
+		if len(c.String("temperature")) > 0 {
+			if data.temperature, err = parseConditionFlag(c, "temperature", VALUE_TYPE_TEMPERATURE); err != nil {
+				return err
+			}
+		}
+		data.atmosphere = calcAtmosphere(data)
+
+		if len(c.String("wind")) > 0 {
+			if data.winds, err = parseWinds(c.String("wind")); err != nil {
+				return flagError(err, "wind")
+			}
+		} else if len(c.String("wind-speed")) > 0 {
+			if data.winds, err = parseWinds(c.String("wind-speed")); err != nil {
+				return flagError(err, "wind-speed")
+			}
+			if len(c.String("wind-direction")) > 0 {
+				// Wind directions should not change the units of the projectile output
+				input_units := InputData
+				direction, err := parseFlagValue(c, "wind-direction", VALUE_TYPE_ANGLE)
+				InputData = input_units
+				if err != nil {
+					return err
+				}
+				data.winds[0].Direction = direction * ANGLE_DEGREES_TO_RADIANS
+			}
+		}
+
+		if caliber, found := PelletCaliber(c.String("caliber")); found && len(c.String("pellet")) > 0 {
+			data.caliber = ParsedData{Label: LENGTH_LABEL_METER, Value: caliber}
+		} else if len(c.String("caliber")) > 0 {
+			// Bullet calibers are in inches
+			if data.caliber, err = parseFlagDefault(c, "caliber", VALUE_TYPE_LENGTH, "in"); err != nil {
+				return err
+			}
+		}
+		if len(c.String("ballistic-coefficient")) > 0 {
+			if data.ballistic_coefficient, err = parseFlag(c, "ballistic-coefficient", VALUE_TYPE_BALLISTIC_COEFFICIENT); err != nil {
+				return err
+			}
+		}
+
+		drag_model, found := DragModel(c.String("drag-model"))
+		if ! found {
+			return fmt.Errorf("Unknown drag model %q. Expected one of: %s", c.String("drag-model"), strings.Join(DragModelNames(), ", "))
+		}
+
+		if len(c.String("drag-file")) > 0 {
+			if c.IsSet("drag-model") {
+				return fmt.Errorf("Give either the drag model or the drag file, not both")
+			}
+			drag_table, err := LoadDragFile(c.String("drag-file"))
+			if err != nil {
+				return err
+			}
+			data.drag_table = drag_table
+
+			if data.ballistic_coefficient.Value == 0 {
+				data.ballistic_coefficient = calcSectionalDensity(data)
+			}
+			if data.ballistic_coefficient.Value == 0 {
+				return fmt.Errorf("A drag file requires the projectile caliber and mass, or the ballistic coefficient with a form factor of 1")
+			}
+		} else if len(c.String("pellet")) > 0 {
+			if c.IsSet("drag-model") {
+				return fmt.Errorf("Give either the drag model or the pellet shape, not both")
+			}
+			shape, found := PelletShapeModel(c.String("pellet"))
+			if ! found {
+				return fmt.Errorf("Unknown pellet shape %q. Expected one of: %s", c.String("pellet"), strings.Join(PelletShapeNames(), ", "))
+			}
+			if data.ballistic_coefficient.Value == 0 {
+				data.ballistic_coefficient = calcSectionalDensity(data)
+				data.ballistic_coefficient.Value /= shape.FormFactor
+			}
+			if data.ballistic_coefficient.Value == 0 {
+				return fmt.Errorf("A pellet shape requires the pellet caliber and mass, or the ballistic coefficient")
+			}
+			data.drag_table, _ = DragModel(PELLET_DRAG_MODEL)
+		} else if data.ballistic_coefficient.Value > 0 {
+			data.drag_table = drag_model
+		} else if c.IsSet("drag-model") {
+			return fmt.Errorf("A drag model requires the ballistic coefficient")
 		}
 
+		if len(data.winds) > 0 && (data.drag_table == nil || data.ballistic_coefficient.Value == 0) {
+			return fmt.Errorf("Wind drift requires drag. Give the ballistic coefficient, a drag file or a pellet shape with the wind")
+		}
+
+		if data.bow.SpeedRating > 0 && data.projectile_mass.Value == 0 {
+			return fmt.Errorf("The IBO speed rating requires the arrow mass")
+		}
+		if data.slingshot.Bands > 0 && data.projectile_mass.Value == 0 {
+			return fmt.Errorf("The slingshot requires the ammo mass")
+		}
+		if len(data.siege.Type) > 0 && data.projectile_mass.Value == 0 {
+			return fmt.Errorf("The siege engine requires the projectile mass")
+		}
 		if data.projectile_velocity.Value == 0 {
-			if data.projectile_mass.Value > 0 && data.draw_length.Value > 0 && data.draw_force.Value > 0 {
+			if len(data.siege.Type) > 0 {
+				data.projectile_velocity = calcSiegeVelocity(data)
+			} else if data.sling.Length > 0 {
+				data.projectile_velocity = calcSlingVelocity(data)
+			} else if data.projectile_mass.Value > 0 && data.slingshot.Bands > 0 {
+				data.projectile_velocity = calcSlingshotVelocity(data)
+			} else if data.projectile_mass.Value > 0 && data.bow.SpeedRating > 0 {
+				data.projectile_velocity = calcRatedVelocity(data)
+			} else if data.projectile_mass.Value > 0 && data.bowSet() {
+				data.projectile_velocity = calcBowVelocity(data)
+			} else if data.projectile_mass.Value > 0 && data.draw_length.Value > 0 && data.draw_force.Value > 0 {
 				data.projectile_velocity = calcVelocity(data)
 			} else if data.projectile_range.Value > 0 && data.projection_angle.Value > 0 {
-				data.projectile_velocity = calcVelocityInitial(data)
+				velocity, found := calcVelocityInitial(data)
+				if ! found {
+					return fmt.Errorf("The projectile can not reach the range of %g %s at %g %s below Mach %g, the fastest the drag model covers", data.projectile_range.UserValue, data.projectile_range.UserLabel, data.projection_angle.UserValue, data.projection_angle.UserLabel, data.drag_table[len(data.drag_table) - 1].Mach)
+				}
+				data.projectile_velocity = velocity
+			}
+		}
+		if data.projectile_mass.Value == 0 || data.projectile_velocity.Value == 0 {
+			data.projectile_mass, data.projectile_velocity = calcMassAndVelocity(data)
+		}
+
+		if len(c.String("energy-limit")) > 0 {
+			if limit, found := EnergyLimitModel(c.String("energy-limit")); found {
+				data.energy_limit = limit.Energy
+			} else {
+				// Energy limits should not change the units of the projectile output
+				input_units := InputData
+				limit, err := parseFlag(c, "energy-limit", VALUE_TYPE_ENERGY)
+				InputData = input_units
+				if parse_err, ok := err.(*ParseError); ok && ! parse_err.NoUnits && ! parse_err.Units {
+					return fmt.Errorf("Unknown energy limit %q for --energy-limit. Expected an energy or one of: %s", c.String("energy-limit"), strings.Join(EnergyLimitNames(), ", "))
+				} else if err != nil {
+					return err
+				}
+				if limit.Value <= 0 {
+					return fmt.Errorf("The energy limit must be greater than zero, not %q. Or give one of: %s", c.String("energy-limit"), strings.Join(EnergyLimitNames(), ", "))
+				}
+				data.energy_limit = limit.Value
+			}
+			if data.projectile_mass.Value == 0 || data.projectile_velocity.Value == 0 {
+				return fmt.Errorf("The energy limit requires the projectile mass and velocity")
+			}
+		}
+
+		if len(c.String("correction-units")) > 0 {
+			units, err := ParseValue("1" + c.String("correction-units"), VALUE_TYPE_ANGLE)
+			if err != nil || units.UserLabel == ANGLE_LABEL_CLOCK {
+				return fmt.Errorf("Unknown correction units %q. Expected one of: moa, smoa, mrad or mil", c.String("correction-units"))
+			}
+			output_angle = units.UserLabel
+		}
+		switch units := strings.ToLower(c.String("units")); units {
+		case UNIT_SYSTEM_IMPERIAL, UNIT_SYSTEM_INPUT, UNIT_SYSTEM_METRIC:
+			output_units = units
+		default:
+			source := "--units"
+			if c.String("units") == os.Getenv("BALLISTIC_UNITS") {
+				source = "the BALLISTIC_UNITS environment variable"
+			}
+			return fmt.Errorf("Unknown units system %q for %s. Expected one of: metric, imperial or input", c.String("units"), source)
+		}
+		if output_energy, err = parseOutputUnits(c, "out-energy", VALUE_TYPE_ENERGY); err != nil {
+			return err
+		}
+		if output_momentum, err = parseOutputUnits(c, "out-momentum", VALUE_TYPE_MOMENTUM); err != nil {
+			return err
+		}
+		if output_range, err = parseOutputUnits(c, "out-range", VALUE_TYPE_LENGTH); err != nil {
+			return err
+		}
+		if output_velocity, err = parseOutputUnits(c, "out-velocity", VALUE_TYPE_VELOCITY); err != nil {
+			return err
+		}
+
+		if len(c.String("click")) > 0 {
+			click, err := parseFlag(c, "click", VALUE_TYPE_ANGLE)
+			if err != nil {
+				return err
+			}
+			if click.Value <= 0 {
+				return fmt.Errorf("The click value must be greater than zero")
+			}
+			output_click = click.Value * ANGLE_DEGREES_TO_RADIANS
+		}
+
+		if len(c.String("sight-height")) > 0 {
+			if data.sight_height, err = parseFlag(c, "sight-height", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+		}
+		if len(c.String("zero-range")) > 0 {
+			if data.zero_range, err = parsePositiveFlag(c, "zero-range", VALUE_TYPE_LENGTH); err != nil {
+				return err
 			}
 		}
 
-		data.target_radius = ParseValue(c.String("radius"), VALUE_TYPE_LENGTH)
+		if len(c.String("table-start")) > 0 {
+			if data.table_start, err = parseFlag(c, "table-start", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+		}
+		if len(c.String("table-step")) > 0 {
+			if data.table_step, err = parseFlag(c, "table-step", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+		}
+		if len(c.String("table-stop")) > 0 {
+			if data.table_stop, err = parseFlag(c, "table-stop", VALUE_TYPE_LENGTH); err != nil {
+				return err
+			}
+		}
+
+		// The default radius should not change the units of the output
+		input_units := InputData
+		if data.target_radius, err = parseFlag(c, "radius", VALUE_TYPE_LENGTH); err != nil {
+			return err
+		}
+		if ! c.IsSet("radius") {
+			InputData = input_units
+		}
+
+		if len(data.siege.Type) > 0 && len(data.zero_range.UserLabel) > 0 {
+			return fmt.Errorf("The zero range does not apply to siege engines")
+		}
+
+		// Siege engines have no sights to zero
+		if data.projectile_velocity.Value > 0 && len(data.siege.Type) == 0 {
+			data.mpbr_zero = calcMPBR(data)
+			data.mpbr.Value = data.mpbr_zero.PointBlank.Distance
+			data.mpbr.Label = LENGTH_LABEL_METER
+
+			data.zero = data.mpbr_zero
+			if len(data.zero_range.UserLabel) > 0 {
+				zero, found := calcZero(data)
+				if ! found {
+					return fmt.Errorf("The projectile can not reach the zero range of %g %s", data.zero_range.UserValue, data.zero_range.UserLabel)
+				}
+				data.zero = zero
+			}
+		}
 
 		if data.projectile_velocity.Value > 0 {
-			data.mpbr = calcMPBR(data)
+			if len(data.siege.Type) > 0 && data.projection_angle.Value == 0 {
+				data.projection_angle.Value = data.siege.ReleaseAngle(data.projectile_velocity.Value)
+				data.projection_angle.Label = ANGLE_LABEL_DEGREES
+			}
+			if data.projectile_range.Value == 0 && data.projection_angle.Value > 0 {
+				data.projectile_range = calcRange(data)
+			}
+		}
+
+		if len(data.table_step.UserLabel) > 0 && data.table_step.Value <= 0 {
+			return fmt.Errorf("The table step must be greater than zero")
+		}
+		if data.table_start.Value < 0 {
+			return fmt.Errorf("The table start must not be negative")
+		}
+		if len(data.table_stop.UserLabel) > 0 && data.table_stop.Value < data.table_start.Value {
+			return fmt.Errorf("The table stop must not be before the table start")
 		}
 
 		buildOutputData(data)
@@ -759,12 +2866,6 @@ func main() {
 		log.Fatal(err)
 	}
 
-	BALLISTIC_WEIGHT, bw_present := os.LookupEnv("BALLISTIC_WEIGHT")
-
-	if bw_present {
-		fmt.Printf("BALLISTIC_WEIGHT: %s", BALLISTIC_WEIGHT)
-	}
-
 	// x := fmt.Sprintf("Hello, %s!", )
 	// x := fmt.Sprintf("Hello, %s!", os.Args[1])
 }
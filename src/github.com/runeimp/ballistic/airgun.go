@@ -0,0 +1,141 @@
+/**
+ * Ballistic.airgun
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+
+//
+// Structs
+//
+
+/** Legal muzzle energy limit for air guns */
+type EnergyLimit struct {
+	Energy float64 // Joules
+	Name string
+}
+
+
+/** Air gun pellet head shape with its form factor against the GA drag model */
+type PelletShape struct {
+	FormFactor float64
+	Name string
+}
+
+
+//
+// CONSTANTS
+//
+const PELLET_DRAG_MODEL = "GA"
+
+
+//
+// VARIABLES
+//
+var EnergyLimits map[string]EnergyLimit = map[string]EnergyLimit{
+	"germany":   EnergyLimit{Energy: 7.5, Name: "germany"},                                     // F mark air guns
+	"uk":        EnergyLimit{Energy: 12 * ENERGY_FROM_FOOTPOUNDS_TO_JOULES, Name: "uk"},        // Air rifles without a firearms certificate
+	"uk-pistol": EnergyLimit{Energy: 6 * ENERGY_FROM_FOOTPOUNDS_TO_JOULES, Name: "uk-pistol"}, // Air pistols
+}
+
+/** Nominal air gun calibers and their pellet head diameters in meters */
+var PelletCalibers map[string]float64 = map[string]float64{
+	"177": 0.0045,
+	"20":  0.0050,
+	"22":  0.0055,
+	"25":  0.00635,
+	"30":  0.00762,
+	"357": 0.0090,
+	"45":  0.01143,
+}
+
+/**
+ * Typical pellet head shapes
+ *
+ * Form factors are against the GA drag model for typical pellets of each shape.
+ * Domed pellets fly best while wadcutters punch clean holes in paper at short
+ * range and slow down quickly.
+ */
+var PelletShapes map[string]PelletShape = map[string]PelletShape{
+	"domed":        PelletShape{FormFactor: 1.65, Name: "domed"},
+	"hollow-point": PelletShape{FormFactor: 2.30, Name: "hollow-point"},
+	"pointed":      PelletShape{FormFactor: 1.90, Name: "pointed"},
+	"wadcutter":    PelletShape{FormFactor: 3.20, Name: "wadcutter"},
+}
+
+
+//
+// FUNCTIONS
+//
+
+/** Returns the legal energy limit for the name (uk, uk-pistol or germany) */
+func EnergyLimitModel(name string) (limit EnergyLimit, found bool) {
+	limit, found = EnergyLimits[strings.ToLower(name)]
+	return limit, found
+}
+
+
+/** Returns the sorted list of legal energy limit names */
+func EnergyLimitNames() (names []string) {
+	for name := range EnergyLimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+
+/** Returns the pellet head diameter in meters for a nominal caliber such as .177 or .22 */
+func PelletCaliber(name string) (caliber float64, found bool) {
+	caliber, found = PelletCalibers[strings.TrimPrefix(name, ".")]
+	return caliber, found
+}
+
+
+/** Returns the pellet head shape for the name (domed, pointed, hollow-point or wadcutter) */
+func PelletShapeModel(name string) (shape PelletShape, found bool) {
+	shape, found = PelletShapes[strings.ToLower(name)]
+	return shape, found
+}
+
+
+/** Returns the sorted list of pellet head shapes */
+func PelletShapeNames() (names []string) {
+	for name := range PelletShapes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+
+/** Calculate the highest velocity in meters per second a projectile of the given mass in kilograms can have within the energy limit in joules */
+func LimitVelocity(energy, mass float64) float64 {
+	if mass <= 0 {
+		return 0.0
+	}
+
+	return math.Sqrt(2 * energy / mass)
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
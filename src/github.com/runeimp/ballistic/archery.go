@@ -0,0 +1,431 @@
+/**
+ * Ballistic.archery
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+)
+
+
+//
+// Structs
+//
+type Bow struct {
+	BraceHeight float64 // Meters from the grip pivot to the string at rest
+	Cam BowCam          // Compound cam profile
+	Curve DrawCurve     // Measured draw force curve. Used in place of the draw weight and bow type when set.
+	DrawLength float64  // AMO draw length in meters
+	DrawWeight float64  // Peak draw force in newtons
+	Efficiency float64  // Fraction of the stored energy delivered to the arrow. Used in place of the virtual mass when set.
+	EnergyRatio float64 // Stored energy as a fraction of the peak draw force times the power stroke. Longbows, recurves and crossbows.
+	LetOff float64      // Fraction of the peak draw force let off at full draw. Compounds and compound crossbows only.
+	SpeedRating float64 // IBO/ATA rated arrow velocity in meters per second. Used in place of the stored energy when set.
+	StringMass float64  // Kilograms of peep sights, D-loops, silencers, etc. on the string
+	Stroke float64      // Power stroke in meters. Used in place of the draw length and brace height when set.
+	Type string
+	VirtualMass float64 // Kilograms of the limbs and string effectively moving with the arrow
+}
+
+/**
+ * Compound cam profile
+ *
+ * The draw force rises to the peak weight over the first part of the power
+ * stroke, holds at peak and then falls off to the holding weight over the
+ * last part. Harder cams rise and fall faster storing more energy.
+ */
+type BowCam struct {
+	Fall float64 // Fraction of the power stroke falling from peak to holding weight
+	Rise float64 // Fraction of the power stroke rising to peak weight
+}
+
+
+type DrawPoint struct {
+	Draw float64  // Draw length in meters
+	Force float64 // Draw force in newtons
+}
+
+/** Draw force versus draw length sorted by draw length */
+type DrawCurve []DrawPoint
+
+
+//
+// CONSTANTS
+//
+const BOW_AMO_DRAW_LENGTH_OFFSET float64 = 0.04445 // meters (1.75 inches) AMO draw length is measured to 1.75" past the pivot point
+
+const BOW_CAM_DEFAULT = "medium"
+
+const BOW_IBO_ARROW_MASS float64 = 350 * MASS_FROM_GRAINS_TO_KILOGRAMS // 350 grains
+const BOW_IBO_DRAW_LENGTH float64 = 30 * LENGTH_FROM_INCHES_TO_METERS  // 30 inches
+const BOW_IBO_DRAW_WEIGHT float64 = 70 * FORCE_FROM_POUNDS_TO_NEWTONS  // 70 pounds
+
+const BOW_IBO_ARROW_MASS_RATE float64 = 1.0 / 3.0 // feet per second per grain
+const BOW_IBO_DRAW_LENGTH_RATE float64 = 10.0    // feet per second per inch
+const BOW_IBO_DRAW_WEIGHT_RATE float64 = 2.5     // feet per second per pound
+
+const BOW_TYPE_COMPOUND = "compound"
+const BOW_TYPE_CROSSBOW = "crossbow"
+const BOW_TYPE_LONGBOW = "longbow"
+const BOW_TYPE_RECURVE = "recurve"
+
+
+//
+// VARIABLES
+//
+var BowCams map[string]BowCam = map[string]BowCam{
+	"soft":   BowCam{Fall: 0.25, Rise: 0.40},
+	"medium": BowCam{Fall: 0.20, Rise: 0.30},
+	"hard":   BowCam{Fall: 0.15, Rise: 0.20},
+}
+
+/**
+ * Typical bows of each type
+ *
+ * Energy ratios and virtual masses are typical of modern bows of each type.
+ * The virtual masses give efficiencies of about 80% for compounds with IBO
+ * arrows (5 grains per pound), 75% for recurves with 10 grains per pound,
+ * and 70% for longbows and crossbows with typical arrows and bolts.
+ * Longbows stack near full draw storing less energy than a linear spring.
+ * Crossbows have no AMO draw length so the draw length less the brace height
+ * is the power stroke.
+ */
+var BowModels map[string]Bow = map[string]Bow{
+	BOW_TYPE_COMPOUND: Bow{BraceHeight: 0.1778, LetOff: 0.80, Type: BOW_TYPE_COMPOUND, VirtualMass: 0.0057},
+	BOW_TYPE_CROSSBOW: Bow{BraceHeight: 0.0, EnergyRatio: 0.50, Type: BOW_TYPE_CROSSBOW, VirtualMass: 0.0111},
+	BOW_TYPE_LONGBOW:  Bow{BraceHeight: 0.1651, EnergyRatio: 0.44, Type: BOW_TYPE_LONGBOW, VirtualMass: 0.0167},
+	BOW_TYPE_RECURVE:  Bow{BraceHeight: 0.2032, EnergyRatio: 0.50, Type: BOW_TYPE_RECURVE, VirtualMass: 0.0108},
+}
+
+
+//
+// FUNCTIONS
+//
+
+/**
+ * Returns a typical bow of the type (longbow, recurve, compound or crossbow)
+ *
+ * Crossbows get the default cam profile too for compound crossbows given a
+ * let-off.
+ */
+func BowModel(name string) (bow Bow, found bool) {
+	bow, found = BowModels[strings.ToLower(name)]
+	if found && (bow.Type == BOW_TYPE_COMPOUND || bow.Type == BOW_TYPE_CROSSBOW) {
+		bow.Cam = BowCams[BOW_CAM_DEFAULT]
+	}
+	return bow, found
+}
+
+
+/** Returns the sorted list of bow types */
+func BowModelNames() (names []string) {
+	for name := range BowModels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+
+/** Returns the compound cam profile for the name (soft, medium or hard) */
+func BowCamProfile(name string) (cam BowCam, found bool) {
+	cam, found = BowCams[strings.ToLower(name)]
+	return cam, found
+}
+
+
+/** Calculate the distance in meters the string pushes the arrow */
+func (bow Bow) PowerStroke() float64 {
+	if bow.Stroke > 0 {
+		return bow.Stroke
+	}
+	if bow.Type == BOW_TYPE_CROSSBOW {
+		return bow.DrawLength - bow.BraceHeight
+	}
+	return bow.DrawLength - bow.BraceHeight - BOW_AMO_DRAW_LENGTH_OFFSET
+}
+
+
+/**
+ * Estimate the energy in joules stored in the drawn bow
+ *
+ * This is the area under the draw force curve. A measured curve is used if
+ * set. Otherwise compounds and crossbows with a let-off are modeled as a rise
+ * to peak weight, a plateau and a fall to the holding weight.
+ */
+func (bow Bow) StoredEnergy() float64 {
+	if len(bow.Curve) > 0 {
+		return bow.Curve.Energy(bow.DrawLength)
+	}
+
+	work := bow.DrawWeight * bow.PowerStroke()
+
+	if bow.Type != BOW_TYPE_COMPOUND && bow.LetOff == 0 {
+		return work * bow.EnergyRatio
+	}
+
+	holding := 1 - bow.LetOff
+	plateau := 1 - bow.Cam.Rise - bow.Cam.Fall
+
+	return work * (bow.Cam.Rise * 0.5 + plateau + bow.Cam.Fall * (1 + holding) * 0.5)
+}
+
+
+/**
+ * Calculate the fraction of the stored energy delivered to an arrow of the given mass in kilograms
+ *
+ * This is the Klopsteg virtual mass model where the limbs and string move
+ * with the arrow as if they were a mass added to it. Heavier arrows take a
+ * larger share of the energy. A fixed efficiency is used in place of the
+ * virtual mass when set and all of the energy is delivered without either.
+ *
+ * @see Klopsteg, P. E. (1943) "Physics of Bows and Arrows", American Journal of Physics 11
+ */
+func (bow Bow) EfficiencyFor(mass float64) float64 {
+	if bow.Efficiency > 0 {
+		return bow.Efficiency
+	}
+	if bow.VirtualMass > 0 && mass > 0 {
+		return mass / (mass + bow.VirtualMass)
+	}
+	return 1.0
+}
+
+
+/** Calculate the energy in joules delivered to an arrow of the given mass in kilograms */
+func (bow Bow) DeliveredEnergy(mass float64) float64 {
+	return bow.EfficiencyFor(mass) * bow.StoredEnergy()
+}
+
+
+/** Calculate the velocity in meters per second of an arrow of the given mass in kilograms */
+func (bow Bow) ArrowVelocity(mass float64) float64 {
+	if mass <= 0 {
+		return 0.0
+	}
+
+	return math.Sqrt(2 * bow.DeliveredEnergy(mass) / mass)
+}
+
+
+/**
+ * Estimate the velocity in meters per second of an arrow of the given mass in kilograms from the IBO/ATA speed rating
+ *
+ * The rating is measured at 70 lb draw weight, 30" draw length with a 350 gr
+ * arrow and nothing on the string. The common rules of thumb take off 10 fps
+ * per inch of draw length under 30", 2.5 fps per pound of draw weight under
+ * 70 lb and 1 fps per 3 grains of arrow and string extras over 350 gr. The
+ * IBO draw length and weight are used if not set.
+ */
+func (bow Bow) RatedVelocity(mass float64) float64 {
+	draw_length := bow.DrawLength
+	if draw_length == 0 {
+		draw_length = BOW_IBO_DRAW_LENGTH
+	}
+	draw_weight := bow.DrawWeight
+	if draw_weight == 0 {
+		draw_weight = BOW_IBO_DRAW_WEIGHT
+	}
+
+	fps := bow.SpeedRating * VELOCITY_FROM_MPS_TO_FPS
+	fps -= (BOW_IBO_DRAW_LENGTH - draw_length) / LENGTH_FROM_INCHES_TO_METERS * BOW_IBO_DRAW_LENGTH_RATE
+	fps -= (BOW_IBO_DRAW_WEIGHT - draw_weight) / FORCE_FROM_POUNDS_TO_NEWTONS * BOW_IBO_DRAW_WEIGHT_RATE
+	fps -= (mass + bow.StringMass - BOW_IBO_ARROW_MASS) / MASS_FROM_GRAINS_TO_KILOGRAMS * BOW_IBO_ARROW_MASS_RATE
+
+	return math.Max(fps, 0) * VELOCITY_FROM_FPS_TO_MPS
+}
+
+
+/**
+ * Integrate the draw force curve for the energy in joules stored up to the draw length in meters
+ *
+ * The whole curve is integrated if the draw length is 0 or past the end of
+ * the curve.
+ */
+func (curve DrawCurve) Energy(draw_length float64) (energy float64) {
+	for i := 1; i < len(curve); i++ {
+		a := curve[i - 1]
+		b := curve[i]
+
+		if draw_length > 0 && b.Draw > draw_length {
+			if a.Draw < draw_length {
+				force := a.Force + (b.Force - a.Force) * (draw_length - a.Draw) / (b.Draw - a.Draw)
+				energy += (a.Force + force) * 0.5 * (draw_length - a.Draw)
+			}
+			break
+		}
+
+		energy += (a.Force + b.Force) * 0.5 * (b.Draw - a.Draw)
+	}
+
+	return energy
+}
+
+
+/** Returns the peak draw force in newtons */
+func (curve DrawCurve) PeakForce() (peak float64) {
+	for _, point := range curve {
+		peak = math.Max(peak, point.Force)
+	}
+	return peak
+}
+
+
+/**
+ * Load a measured draw force curve from a CSV file
+ *
+ * Each line holds a draw length and the draw force at that length. Values
+ * may have any length and force suffix. A header line may give the units for
+ * values without a suffix as `draw (in),force (lb)` and lines starting with #
+ * are ignored. The curve should start at brace height with no force.
+ */
+func LoadDrawCurve(path string) (curve DrawCurve, err error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Units in the curve should not change the units of the output
+	input_units := InputData
+	defer func() { InputData = input_units }()
+
+	curve, err = parseDrawCSV(string(content))
+	if err == nil {
+		err = curve.Validate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Draw curve %s: %s", path, err)
+	}
+
+	return curve, nil
+}
+
+
+func parseDrawCSV(content string) (curve DrawCurve, err error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	draw_units := ""
+	force_units := ""
+
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Comment lines are skipped so the record index is not the line
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected a draw length and draw force", line)
+		}
+
+		draw := strings.Replace(record[0], " ", "", -1)
+		force := strings.Replace(record[1], " ", "", -1)
+
+		if ! isNumeric(draw) || ! isNumeric(force) {
+			if i == 0 {
+				draw_units = headerUnits(draw, VALUE_TYPE_LENGTH)
+				force_units = headerUnits(force, VALUE_TYPE_FORCE)
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid number in %q", line, strings.Join(record, ","))
+		}
+
+		if ! hasSuffix(draw) {
+			draw += draw_units
+		}
+		if ! hasSuffix(force) {
+			force += force_units
+		}
+
+		draw_value, err := ParseValue(draw, VALUE_TYPE_LENGTH)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		force_value, err := ParseValue(force, VALUE_TYPE_FORCE)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+
+		curve = append(curve, DrawPoint{Draw: draw_value.Value, Force: force_value.Value})
+	}
+
+	return curve, nil
+}
+
+
+/** Returns the units in a CSV header cell such as `draw (in)` or `in` if they are known units of the value type */
+func headerUnits(cell, value_type string) string {
+	units := cell
+	if open := strings.Index(cell, "("); open >= 0 {
+		if close := strings.Index(cell[open:], ")"); close >= 0 {
+			units = strings.TrimSpace(cell[open + 1:open + close])
+		}
+	}
+
+	if _, err := ParseValue("1" + units, value_type); err == nil {
+		return units
+	}
+	return ""
+}
+
+
+/** Returns true if the value starts with a number */
+func isNumeric(value string) bool {
+	match := VALUE_RE.FindStringSubmatch(value)
+	return match != nil && len(match[1]) > 0 && match[1] != "-" && match[1] != "."
+}
+
+
+/** Returns true if the value has a units suffix */
+func hasSuffix(value string) bool {
+	match := VALUE_RE.FindStringSubmatch(value)
+	return match != nil && len(match[2]) > 0
+}
+
+
+/** Check the curve has enough points, strictly increasing draw lengths and no negative forces */
+func (curve DrawCurve) Validate() error {
+	if len(curve) < 2 {
+		return fmt.Errorf("at least 2 draw length and force pairs are required, found %d", len(curve))
+	}
+
+	for i, point := range curve {
+		if point.Force < 0 {
+			return fmt.Errorf("point %d: draw force %g is negative", i + 1, point.Force)
+		}
+		if i > 0 && point.Draw <= curve[i - 1].Draw {
+			return fmt.Errorf("point %d: draw length %g does not increase from %g", i + 1, point.Draw, curve[i - 1].Draw)
+		}
+	}
+
+	return nil
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
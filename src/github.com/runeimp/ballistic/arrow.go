@@ -0,0 +1,230 @@
+/**
+ * Ballistic.arrow
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"fmt"
+)
+
+
+//
+// Structs
+//
+type Arrow struct {
+	BalancePoint float64 // Meters from the nock throat to the balance point. Estimated from the components when not set.
+	Fletches int         // Number of vanes or feathers
+	Fletching float64    // Kilograms of each vane or feather
+	Insert float64       // Kilograms
+	Length float64       // Meters from the nock throat to the end of the shaft
+	Nock float64         // Kilograms
+	Point float64        // Kilograms of the point, field tip or broadhead
+	Shaft float64        // Kilograms per meter of shaft
+}
+
+
+/** Hunting game class with the minimum arrow energy and momentum recommended for it */
+type GameClass struct {
+	Energy float64   // Minimum kinetic energy in joules
+	Examples string
+	Momentum float64 // Minimum momentum in kilogram meters per second
+	Name string
+}
+
+
+//
+// CONSTANTS
+//
+const ARROW_FLETCHING_OFFSET float64 = 0.0635 // meters (2.5 inches) from the nock throat to the middle of the fletching
+
+const ARROW_SPINE_LABEL = "thousandths of an inch"
+const ARROW_SPINE_POINT_RATE float64 = 3.0 / 25.0   // pounds of draw weight per grain of point weight over 100 grains
+const ARROW_SPINE_REFERENCE float64 = 320           // thousandths of an inch deflection for 70 lb with 28" draw and arrow length
+const ARROW_SPINE_REFERENCE_LENGTH float64 = 28 * LENGTH_FROM_INCHES_TO_METERS
+const ARROW_SPINE_REFERENCE_POINT float64 = 100 * MASS_FROM_GRAINS_TO_KILOGRAMS
+const ARROW_SPINE_REFERENCE_WEIGHT float64 = 70 * FORCE_FROM_POUNDS_TO_NEWTONS
+const ARROW_SPINE_TOLERANCE float64 = 0.10          // fraction of the required spine a shaft may be off by and still match
+const ARROW_SPINE_TRADITIONAL_FACTOR float64 = 0.80 // longbows and recurves store less energy than a compound of the same peak weight
+
+const ARROW_SPINE_MATCHED = "matched"
+const ARROW_SPINE_STIFF = "stiff"
+const ARROW_SPINE_WEAK = "weak"
+
+
+//
+// VARIABLES
+//
+
+/**
+ * Hunting game classes from the smallest to the largest
+ *
+ * Energy thresholds are the widely published Easton chart (25, 42 and 65
+ * ft-lbs) and momentum thresholds the matching 0.25, 0.40 and 0.55 slug-ft/s.
+ */
+var GameClasses []GameClass = []GameClass{
+	GameClass{Energy: 0, Examples: "rabbit, squirrel, turkey", Momentum: 0, Name: "small"},
+	GameClass{Energy: 25 * ENERGY_FROM_FOOTPOUNDS_TO_JOULES, Examples: "deer, antelope", Momentum: 0.25 * FORCE_FROM_POUNDS_TO_NEWTONS, Name: "medium"},
+	GameClass{Energy: 42 * ENERGY_FROM_FOOTPOUNDS_TO_JOULES, Examples: "elk, black bear, wild boar", Momentum: 0.40 * FORCE_FROM_POUNDS_TO_NEWTONS, Name: "large"},
+	GameClass{Energy: 65 * ENERGY_FROM_FOOTPOUNDS_TO_JOULES, Examples: "cape buffalo, grizzly bear", Momentum: 0.55 * FORCE_FROM_POUNDS_TO_NEWTONS, Name: "dangerous"},
+}
+
+
+//
+// FUNCTIONS
+//
+
+/** Calculate the total arrow mass in kilograms */
+func (arrow Arrow) Mass() float64 {
+	fletching := arrow.Fletching * float64(arrow.Fletches)
+
+	return arrow.Shaft * arrow.Length + arrow.Point + arrow.Insert + arrow.Nock + fletching
+}
+
+
+/**
+ * Estimate the balance point in meters from the nock throat
+ *
+ * The measured balance point is used if set. Otherwise the point and insert
+ * sit at the end of the shaft, the nock at the nock throat and the fletching
+ * 2.5" ahead of it.
+ */
+func (arrow Arrow) Balance() float64 {
+	if arrow.BalancePoint > 0 {
+		return arrow.BalancePoint
+	}
+
+	mass := arrow.Mass()
+	if mass <= 0 {
+		return 0.0
+	}
+
+	shaft := arrow.Shaft * arrow.Length * arrow.Length * 0.5
+	front := (arrow.Point + arrow.Insert) * arrow.Length
+	fletching := arrow.Fletching * float64(arrow.Fletches) * ARROW_FLETCHING_OFFSET
+
+	return (shaft + front + fletching) / mass
+}
+
+
+/**
+ * Calculate the FOC (front of center) as the fraction of the arrow length the balance point is ahead of the middle
+ *
+ * @see Easton Arrow Tuning and Maintenance Guide
+ */
+func (arrow Arrow) FOC() float64 {
+	if arrow.Length <= 0 {
+		return 0.0
+	}
+
+	return (arrow.Balance() - arrow.Length * 0.5) / arrow.Length
+}
+
+
+/** Check the arrow has a shaft, length and balance point within the arrow */
+func (arrow Arrow) Validate() error {
+	if arrow.Length <= 0 || arrow.Shaft <= 0 {
+		return fmt.Errorf("The arrow components require the arrow length and shaft weight")
+	}
+	if arrow.BalancePoint > arrow.Length {
+		return fmt.Errorf("The arrow balance point must be within the arrow length")
+	}
+
+	return nil
+}
+
+
+/**
+ * Estimate the static spine (deflection in thousandths of an inch) the arrow needs to shoot from the bow
+ *
+ * The ATA static spine is the deflection of the shaft under 1.94 lb hung from
+ * the middle of a 28" span. Stiffer shafts deflect less. The effective draw
+ * weight is the peak draw weight scaled by the draw length plus 3 lb for
+ * every 25 grains of point weight over 100 grains (Easton). The required
+ * deflection falls with the effective weight and the square of the arrow
+ * length from 0.320" for 70 lb with a 28" draw and arrow. Longbows and
+ * recurves need weaker shafts than compounds of the same peak weight. The
+ * draw length is used for the arrow length and a 100 grain point if not set.
+ */
+func RequiredSpine(bow Bow, arrow Arrow) float64 {
+	weight := bow.DrawWeight
+	if weight == 0 {
+		weight = bow.Curve.PeakForce()
+	}
+	if bow.DrawLength > 0 {
+		weight *= bow.DrawLength / ARROW_SPINE_REFERENCE_LENGTH
+	}
+	if bow.Type == BOW_TYPE_LONGBOW || bow.Type == BOW_TYPE_RECURVE {
+		weight *= ARROW_SPINE_TRADITIONAL_FACTOR
+	}
+	if arrow.Point > 0 {
+		point := (arrow.Point - ARROW_SPINE_REFERENCE_POINT) / MASS_FROM_GRAINS_TO_KILOGRAMS
+		weight += point * ARROW_SPINE_POINT_RATE * FORCE_FROM_POUNDS_TO_NEWTONS
+	}
+
+	length := arrow.Length
+	if length == 0 {
+		length = bow.DrawLength
+	}
+	if weight <= 0 || length <= 0 {
+		return 0.0
+	}
+
+	length_ratio := ARROW_SPINE_REFERENCE_LENGTH / length
+
+	return ARROW_SPINE_REFERENCE * ARROW_SPINE_REFERENCE_WEIGHT / weight * length_ratio * length_ratio
+}
+
+
+/** Returns whether the shaft spine is weak, stiff or matched to the required spine */
+func SpineMatch(spine, required float64) string {
+	switch {
+	case spine > required * (1 + ARROW_SPINE_TOLERANCE):
+		return ARROW_SPINE_WEAK
+	case spine < required * (1 - ARROW_SPINE_TOLERANCE):
+		return ARROW_SPINE_STIFF
+	}
+	return ARROW_SPINE_MATCHED
+}
+
+
+/** Returns the game class as i.e. large game (elk, black bear, wild boar) */
+func (class GameClass) String() string {
+	return fmt.Sprintf("%s game (%s)", class.Name, class.Examples)
+}
+
+
+/** Returns the largest game class the energy in joules is recommended for */
+func EnergyGameClass(energy float64) (class GameClass) {
+	for _, game := range GameClasses {
+		if energy >= game.Energy {
+			class = game
+		}
+	}
+	return class
+}
+
+
+/** Returns the largest game class the momentum in kilogram meters per second is recommended for */
+func MomentumGameClass(momentum float64) (class GameClass) {
+	for _, game := range GameClasses {
+		if momentum >= game.Momentum {
+			class = game
+		}
+	}
+	return class
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
@@ -0,0 +1,104 @@
+/**
+ * Ballistic.atmosphere
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"math"
+)
+
+
+//
+// Structs
+//
+type Atmosphere struct {
+	Altitude float64    // Meters above sea level
+	Humidity float64    // Relative humidity from 0 to 1
+	Pressure float64    // Station (absolute) pressure in pascals
+	Temperature float64 // Kelvin
+}
+
+
+//
+// CONSTANTS
+//
+const ATMOSPHERE_GAS_CONSTANT_DRY_AIR float64 = 287.058 // J/(kg⋅K)
+const ATMOSPHERE_GAS_CONSTANT_WATER_VAPOR float64 = 461.495 // J/(kg⋅K)
+const ATMOSPHERE_HEAT_CAPACITY_RATIO float64 = 1.4
+const ATMOSPHERE_LAPSE_RATE float64 = 0.0065 // Kelvin per meter in the troposphere
+const ATMOSPHERE_PRESSURE_EXPONENT float64 = 5.25588
+const ATMOSPHERE_STANDARD_PRESSURE float64 = 101325.0 // pascals at sea level
+const ATMOSPHERE_STANDARD_TEMPERATURE float64 = 288.15 // Kelvin at sea level
+
+
+//
+// FUNCTIONS
+//
+
+/** Returns the ICAO standard atmosphere (dry air) at the given altitude in meters */
+func StandardAtmosphere(altitude float64) (atmosphere Atmosphere) {
+	atmosphere.Altitude = altitude
+	atmosphere.Temperature = ATMOSPHERE_STANDARD_TEMPERATURE - ATMOSPHERE_LAPSE_RATE * altitude
+	atmosphere.Pressure = StationPressure(ATMOSPHERE_STANDARD_PRESSURE, altitude)
+
+	return atmosphere
+}
+
+
+/** Convert barometric pressure (corrected to sea level) to station pressure at the given altitude */
+func StationPressure(barometric_pressure, altitude float64) float64 {
+	ratio := 1 - ATMOSPHERE_LAPSE_RATE * altitude / ATMOSPHERE_STANDARD_TEMPERATURE
+	return barometric_pressure * math.Pow(ratio, ATMOSPHERE_PRESSURE_EXPONENT)
+}
+
+
+/** Returns true if the atmosphere has not been set and the sea level standard should be used */
+func (atmosphere Atmosphere) IsZero() bool {
+	return atmosphere.Temperature == 0 && atmosphere.Pressure == 0
+}
+
+
+/**
+ * Calculate air density in kilograms per cubic meter
+ *
+ * Humid air is a mix of dry air and water vapor each contributing their
+ * partial pressure. The saturation vapor pressure is from the Tetens equation.
+ */
+func (atmosphere Atmosphere) Density() float64 {
+	if atmosphere.IsZero() {
+		return AIR_DENSITY_STANDARD_KGPM3
+	}
+
+	celsius := atmosphere.Temperature - TEMPERATURE_FROM_CELSIUS_TO_KELVIN
+	saturation_pressure := 610.78 * math.Pow(10, 7.5 * celsius / (celsius + 237.3))
+	vapor_pressure := atmosphere.Humidity * saturation_pressure
+	dry_pressure := atmosphere.Pressure - vapor_pressure
+
+	return dry_pressure / (ATMOSPHERE_GAS_CONSTANT_DRY_AIR * atmosphere.Temperature) +
+		vapor_pressure / (ATMOSPHERE_GAS_CONSTANT_WATER_VAPOR * atmosphere.Temperature)
+}
+
+
+/** Calculate the speed of sound in meters per second */
+func (atmosphere Atmosphere) SpeedOfSound() float64 {
+	if atmosphere.IsZero() {
+		return SPEED_OF_SOUND_STANDARD_MPS
+	}
+
+	return math.Sqrt(ATMOSPHERE_HEAT_CAPACITY_RATIO * ATMOSPHERE_GAS_CONSTANT_DRY_AIR * atmosphere.Temperature)
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
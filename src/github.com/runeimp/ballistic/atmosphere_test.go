@@ -0,0 +1,76 @@
+/**
+ * Ballistic.atmosphere tests
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"testing"
+)
+
+
+//
+// FUNCTIONS
+//
+
+func TestStandardAtmosphere(t *testing.T) {
+	tests := []struct {
+		altitude float64
+		temperature float64
+		pressure float64
+		density float64
+		speed_of_sound float64
+	}{
+		// ICAO standard atmosphere
+		{0, 288.15, 101325, 1.2250, 340.29},
+		{1000, 281.65, 89876, 1.1117, 336.43},
+		{3000, 268.65, 70121, 0.9093, 328.58},
+	}
+
+	for _, test := range tests {
+		atmosphere := StandardAtmosphere(test.altitude)
+		if ! closeTo(atmosphere.Temperature, test.temperature, 1e-6) {
+			t.Errorf("Temperature at %g m = %g K, expected %g K", test.altitude, atmosphere.Temperature, test.temperature)
+		}
+		if ! closeTo(atmosphere.Pressure, test.pressure, 1e-3) {
+			t.Errorf("Pressure at %g m = %g Pa, expected %g Pa", test.altitude, atmosphere.Pressure, test.pressure)
+		}
+		if ! closeTo(atmosphere.Density(), test.density, 1e-3) {
+			t.Errorf("Density at %g m = %g kg/m³, expected %g kg/m³", test.altitude, atmosphere.Density(), test.density)
+		}
+		if ! closeTo(atmosphere.SpeedOfSound(), test.speed_of_sound, 1e-4) {
+			t.Errorf("Speed of sound at %g m = %g m/s, expected %g m/s", test.altitude, atmosphere.SpeedOfSound(), test.speed_of_sound)
+		}
+	}
+}
+
+
+func TestAtmosphereDefaults(t *testing.T) {
+	var atmosphere Atmosphere
+
+	if atmosphere.Density() != AIR_DENSITY_STANDARD_KGPM3 {
+		t.Errorf("Unset density = %g kg/m³, expected %g kg/m³", atmosphere.Density(), AIR_DENSITY_STANDARD_KGPM3)
+	}
+	if ! closeTo(atmosphere.SpeedOfSound(), 340.3, 1e-3) {
+		t.Errorf("Unset speed of sound = %g m/s, expected 340.3 m/s", atmosphere.SpeedOfSound())
+	}
+}
+
+
+func TestAtmosphereHumidity(t *testing.T) {
+	dry := StandardAtmosphere(0)
+	humid := dry
+	humid.Humidity = 1.0
+
+	// Water vapor is lighter than the dry air it displaces
+	if humid.Density() >= dry.Density() {
+		t.Errorf("Saturated air density %g kg/m³ is not less than dry air %g kg/m³", humid.Density(), dry.Density())
+	}
+}
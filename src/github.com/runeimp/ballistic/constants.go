@@ -19,16 +19,33 @@ import (
 //
 // CONSTANTS
 //
+const AIR_DENSITY_STANDARD_KGPM3 float64 = 1.2250 // ICAO standard atmosphere at sea level
+
+const BALLISTIC_COEFFICIENT_FROM_LBPIN2_TO_KGPM2 float64 = 703.069581
+const BALLISTIC_COEFFICIENT_LABEL_KGPM2 = "kilograms per square meter"
+const BALLISTIC_COEFFICIENT_LABEL_LBPIN2 = "pounds per square inch"
+
+const ENERGY_FROM_FOOTPOUNDS_TO_JOULES float64 = 1.355818
 const ENERGY_FROM_JOULES_TO_FOOTPOUNDS = 0.737562
+const ENERGY_FROM_KILOJOULES_TO_JOULES float64 = 1000.0
 const ENERGY_LABEL_FOOTPOUNDS = "foot-pounds"
 const ENERGY_LABEL_JOULES = "joules"
+const ENERGY_LABEL_KILOJOULES = "kilojoules"
 
 const FORCE_FROM_KILOGRAMS_TO_NEWTONS float64 = 9.80665 // kg times meters per second squared
+const FORCE_FROM_POUNDS_TO_NEWTONS float64 = 4.448222
+const FORCE_LABEL_KILOGRAMS = "kilograms-force"
 const FORCE_LABEL_NEWTONS string = "newtons"
 const FORCE_LABEL_FOOTPOUNDS = "foot-pounds"
+const FORCE_LABEL_POUNDS = "pounds-force"
+
+const FREQUENCY_FROM_RPM_TO_RPS float64 = 1.0 / 60.0
+const FREQUENCY_LABEL_RPM = "revolutions per minute"
+const FREQUENCY_LABEL_RPS = "revolutions per second"
+
 const GRAVITY_MPS float64 = 9.80665 // meters per second squared
 
-const HELP_TEMPLATE = `
+const HELP_TEMPLATE_APP = `
 NAME:
    {{.Name}}{{if .Usage}} - {{.Usage}}{{end}}
 
@@ -52,50 +69,27 @@ GLOBAL OPTIONS:
 COPYRIGHT:
    {{.Copyright}}{{end}}
 
-VALUE SUFFIXES:
-  All input values may be suffixed to allow for broader input selection.
-
-  ANGLE
-    d, deg, degree, degrees
-    r, rad, radian, radians
-  LENGTH
-    c, cm, centi, centimeter, centimeters
-    f, ft, foot, feet
-    i, in, inch, inches
-    k, km, kilo, kilometer, kilometers
-    m, meters †
-    M, NM, Nm, nm, nmi  (Nautical Miles)
-    mm, milli, millimeter, millimeters
-    y, yd, yrd, yard, yards
-  MASS
-    #, lb, lbs, pound, pounds
-    g, gram, grams
-    gr, grain, grains
-    kg, kilo, kilogram, kilograms †
-    lt, long-ton
-    mt, tonne, metric-tonne
-    st, stone
-    t, ton, short-ton
-  VELOCITY
-    fps, feet-per-second
-    kmph, kilometers-per-hour
-    kn, kt, knot, knots
-    mph, miles-per-hour
-    mps, meters-per-second †
-
-†  This is the default and will be used if no suffix is specified
-
-If most or all of the input values are in imperial units then the output will use imperial units as well.
-
 `
 
-// ‡  This is the default if you set BALLISTIC_UNITS to imperial instead of metric
-// The environment variable BALLISTIC_UNITS can be defined as imperial or metric. If it is defined the output units will always be of that system. If BALLISTIC_UNITS is not defined and most or all of the input values are in imperial units then the output will use imperial units as well. Otherwise ballistic defaults to metric.
-
-
 const ANGLE_DEGREES_TO_RADIANS float64 = 0.0174533
+const ANGLE_FROM_CLOCK_TO_DEGREES float64 = 30.0
+const ANGLE_FROM_MILLIRADIANS_TO_DEGREES float64 = 0.0572958
+const ANGLE_FROM_MILS_TO_DEGREES float64 = 0.05625 // NATO mils, 6400 to a circle
+const ANGLE_FROM_MOA_TO_DEGREES float64 = 1.0 / 60.0
+const ANGLE_FROM_RADIANS_TO_DEGREES float64 = 57.2958
+const ANGLE_FROM_RADIANS_TO_MILLIRADIANS float64 = 1000.0
+const ANGLE_FROM_RADIANS_TO_MILS float64 = 1018.59164
+const ANGLE_FROM_RADIANS_TO_MOA float64 = 3437.74677
+const ANGLE_FROM_RADIANS_TO_SMOA float64 = 3600.0 // 1 inch at 100 yards
+const ANGLE_FROM_SMOA_TO_DEGREES float64 = 0.0159155
+const ANGLE_LABEL_CLICKS = "clicks"
+const ANGLE_LABEL_CLOCK = "o'clock"
 const ANGLE_LABEL_DEGREES = "degrees"
+const ANGLE_LABEL_MILLIRADIANS = "milliradians"
+const ANGLE_LABEL_MILS = "mils"
+const ANGLE_LABEL_MOA = "minutes of angle"
 const ANGLE_LABEL_RADIANS = "radians"
+const ANGLE_LABEL_SMOA = "shooter's minutes of angle"
 
 const LENGTH_FROM_CENTIMETERS_TO_METERS float64 = 0.01
 const LENGTH_FROM_FEET_TO_METERS float64 = 0.3048
@@ -123,10 +117,16 @@ const LENGTH_LABEL_MILLIMETER = "millimeters"
 const LENGTH_LABEL_NAUTICAL_MILE = "nautical miles"
 const LENGTH_LABEL_YARD = "yards"
 
+const LINEAR_DENSITY_FROM_GPI_TO_KGPM float64 = 0.00255114 // grains per inch to kilograms per meter
+const LINEAR_DENSITY_FROM_GPM_TO_KGPM float64 = 0.001
+const LINEAR_DENSITY_LABEL_GPI = "grains per inch"
+const LINEAR_DENSITY_LABEL_GPM = "grams per meter"
+
 const MASS_FROM_GRAINS_TO_GRAMS float64 = 0.0647989
 const MASS_FROM_GRAINS_TO_KILOGRAMS float64 = 0.0000647989
 const MASS_FROM_GRAMS_TO_KILOGRAMS float64 = 0.001
 const MASS_FROM_KILOGRAMS_TO_POUNDS float64 = 2.20462
+const MASS_FROM_OUNCES_TO_KILOGRAMS float64 = 0.0283495
 const MASS_FROM_POUNDS_TO_GRAMS float64 = 453.592
 const MASS_FROM_POUNDS_TO_KILOGRAMS float64 = 0.453592
 const MASS_FROM_STONE_TO_GRAMS float64 = 6350.288
@@ -140,12 +140,15 @@ const MASS_FROM_TONS_SHORT_TO_GRAMS float64 = 907185.0
 const MASS_FROM_TONS_SHORT_TO_KILOGRAMS float64 = 907.185
 const MASS_LABEL_GRAINS = "grains"
 const MASS_LABEL_GRAMS = "grams"
+const MASS_LABEL_KILOGRAMS = "kilograms"
 const MASS_LABEL_LONG_TON = "long ton"
 const MASS_LABEL_METRIC_TONNE = "metric tonne"
+const MASS_LABEL_OUNCES = "ounces"
 const MASS_LABEL_POUNDS = "pounds"
 const MASS_LABEL_SHORT_TON = "short ton"
 const MASS_LABEL_STONE = "stone"
 
+const MOMENTUM_FROM_LBFPS_TO_MKS float64 = 0.138255 // pound feet per second to kilogram meters per second
 const MOMENTUM_LABEL_FPS = "foot-pound per second"
 const MOMENTUM_LABEL_MKS = "meter kilogram per second"
 const MOMENTUM_LABEL_NS = "newton second"
@@ -153,6 +156,38 @@ const MOMENTUM_LABEL_NS = "newton second"
 // "Kælie"
 
 
+const PERCENT_LABEL = "percent"
+
+const PRESSURE_FROM_HECTOPASCALS_TO_PASCALS float64 = 100.0
+const PRESSURE_FROM_INCHES_OF_MERCURY_TO_PASCALS float64 = 3386.389
+const PRESSURE_FROM_KILOPASCALS_TO_PASCALS float64 = 1000.0
+const PRESSURE_FROM_MILLIMETERS_OF_MERCURY_TO_PASCALS float64 = 133.322
+const PRESSURE_FROM_PSI_TO_PASCALS float64 = 6894.757
+const PRESSURE_LABEL_HECTOPASCALS = "hectopascals"
+const PRESSURE_LABEL_INCHES_OF_MERCURY = "inches of mercury"
+const PRESSURE_LABEL_KILOPASCALS = "kilopascals"
+const PRESSURE_LABEL_MILLIBARS = "millibars"
+const PRESSURE_LABEL_MILLIMETERS_OF_MERCURY = "millimeters of mercury"
+const PRESSURE_LABEL_PASCALS = "pascals"
+const PRESSURE_LABEL_PSI = "pounds per square inch"
+
+const SPEED_OF_SOUND_STANDARD_MPS float64 = 340.294 // ICAO standard atmosphere at sea level
+
+const TEMPERATURE_FROM_CELSIUS_TO_KELVIN float64 = 273.15 // offset
+const TEMPERATURE_FROM_FAHRENHEIT_TO_CELSIUS float64 = 5.0 / 9.0 // after subtracting TEMPERATURE_FAHRENHEIT_FREEZING
+const TEMPERATURE_FAHRENHEIT_FREEZING float64 = 32.0
+const TEMPERATURE_LABEL_CELSIUS = "degrees celsius"
+const TEMPERATURE_LABEL_FAHRENHEIT = "degrees fahrenheit"
+const TEMPERATURE_LABEL_KELVIN = "kelvin"
+
+const TIME_LABEL_SECONDS = "seconds"
+
+const TRAJECTORY_ANGLE_MAX float64 = 0.785398 // radians (45 degrees)
+const TRAJECTORY_ANGLE_MIN float64 = -0.174533 // radians (-10 degrees)
+const TRAJECTORY_MAX_TIME float64 = 120.0 // seconds
+const TRAJECTORY_TIME_STEP float64 = 0.0001 // seconds
+
+
 const VELOCITY_FROM_FPS_TO_MPS float64 = 0.3048
 const VELOCITY_FROM_KMPH_TO_MPS float64 = 0.277778
 const VELOCITY_FROM_KNOTS_TO_KMPH float64 = 1.852
@@ -169,11 +204,23 @@ const VELOCITY_LABEL_MPH = "miles per hour"
 const VELOCITY_LABEL_MPS = "meters per second"
 
 
-var /* const */ VALUE_RE = regexp.MustCompile("([0-9]*[0-9.]?[0-9]*)([a-z#]*)")
+var /* const */ HELP_TEMPLATE = HELP_TEMPLATE_APP + UnitSuffixesHelp() // VALUE SUFFIXES generated from UnitRegistry so the help matches ParseValue
+
+var /* const */ VALUE_RE = regexp.MustCompile("(-?[0-9]*[0-9.]?[0-9]*)([a-zA-Z#°%·⋅/-]*)")
 // var /* const */ VALUE_RE = regexp.MustCompile("([0-9.]+)([a-z#]*)")
+var /* const */ VALUE_TERM_RE = regexp.MustCompile(`^\s*([+-]?)\s*((?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][+-]?[0-9]+)?)\s*((?:[a-zA-Z#°%·⋅/'"]+(?:-[a-zA-Z·⋅/]+)*)?)\s*`)
 const VALUE_TYPE_ANGLE string = "angle"
+const VALUE_TYPE_BALLISTIC_COEFFICIENT string = "ballistic coefficient"
+const VALUE_TYPE_ENERGY string = "energy"
+const VALUE_TYPE_FORCE string = "force"
+const VALUE_TYPE_FREQUENCY string = "frequency"
 const VALUE_TYPE_LENGTH string = "length"
+const VALUE_TYPE_LINEAR_DENSITY string = "linear density"
 const VALUE_TYPE_MASS string = "weight"
+const VALUE_TYPE_MOMENTUM string = "momentum"
+const VALUE_TYPE_PERCENT string = "percent"
+const VALUE_TYPE_PRESSURE string = "pressure"
+const VALUE_TYPE_TEMPERATURE string = "temperature"
 const VALUE_TYPE_VELOCITY string = "velocity"
 
 
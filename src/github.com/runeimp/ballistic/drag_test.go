@@ -0,0 +1,76 @@
+/**
+ * Ballistic.drag tests
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"strings"
+	"testing"
+)
+
+
+//
+// FUNCTIONS
+//
+
+func TestDragCoefficient(t *testing.T) {
+	tests := []struct {
+		model string
+		mach float64
+		cd float64
+	}{
+		{"G1", 1.0, 0.4805},
+		{"G1", 2.0, 0.5934},
+		{"G1", 1.0125, (0.4805 + 0.5136) / 2}, // Halfway between the Mach 1.0 and 1.025 rows
+		{"G1", 1.5 + 0.05 / 4, 0.6573 + (0.6528 - 0.6573) / 4},
+		{"G1", 0.0, 0.2629},
+		{"G1", 6.0, 0.4988}, // Past the end of the table
+		{"g7", 1.0, 0.3803},
+	}
+
+	for _, test := range tests {
+		table, found := DragModel(test.model)
+		if ! found {
+			t.Errorf("DragModel(%q) not found", test.model)
+			continue
+		}
+		if cd := table.Coefficient(test.mach); ! closeTo(cd, test.cd, 1e-9) {
+			t.Errorf("%s Cd at Mach %g = %g, expected %g", test.model, test.mach, cd, test.cd)
+		}
+	}
+
+	if _, found := DragModel("G99"); found {
+		t.Errorf("DragModel(%q) found an unknown model", "G99")
+	}
+}
+
+
+func TestParseDragCSVLines(t *testing.T) {
+	tests := []struct {
+		content string
+		message string
+	}{
+		{"# Measured drag\n# for the 168gr\nmach,cd\n0.5,0.2\n0.9,x\n", "line 5: "},
+		{"mach,cd\n\n0.5,0.2\n0.9\n", "line 4: "},
+	}
+
+	for _, test := range tests {
+		_, err := parseDragCSV(test.content)
+		if err == nil || ! strings.HasPrefix(err.Error(), test.message) {
+			t.Errorf("parseDragCSV(%q) error = %v, expected it to start with %q", test.content, err, test.message)
+		}
+	}
+
+	table, err := parseDragCSV("# Measured drag\nmach,cd\n0.5,0.2\n0.9,0.3\n")
+	if err != nil || len(table) != 2 || table[1] != (DragPoint{Mach: 0.9, Cd: 0.3}) {
+		t.Errorf("parseDragCSV() = %v, %v, expected 2 points", table, err)
+	}
+}
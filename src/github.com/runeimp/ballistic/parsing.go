@@ -13,7 +13,9 @@ package ballistic
 //
 import (
 	// . "github.com/runeimp/ballistic" // Import ballistic into this namespace for constants, etc.
+	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -24,9 +26,13 @@ import (
 //
 type InputUnits struct {
 	Angle string
+	Energy string
 	Length string
 	Mass string
 	Metric bool
+	Momentum string
+	Pressure string
+	Temperature string
 	Velocity string
 	Weight string
 }
@@ -39,6 +45,20 @@ type ParsedData struct {
 }
 
 
+/** User input value that is not a number with known units for its value type */
+type ParseError struct {
+	Flag string          // Command line flag the value was given for. Set by the caller.
+	NoUnits bool         // The token is a term after the first without units
+	NotPositive bool     // The value is zero or less where it must be greater than zero
+	Suffixes []string    // Units suffixes accepted for the value type
+	Suggestions []string // Accepted suffixes close to the unknown units
+	Token string         // The part of the value that could not be parsed
+	Units bool           // The token is unknown units rather than an invalid number
+	Value string
+	ValueType string
+}
+
+
 //
 // VARIABLES
 //
@@ -46,160 +66,286 @@ var InputData InputUnits
 var output_debug bool = false // NOTE: Temporary!!
 
 
-/** Parse user input value and normalize it for internal use */
-func ParseValue(value, value_type string) (parsed_data ParsedData) {
+//
+// FUNCTIONS
+//
+
+/**
+ * Returns the error message naming the flag, value type and accepted units
+ *
+ * i.e. Invalid velocity "900fs" for --velocity. Unknown units "fs", did you mean fps? Expected a number optionally followed by one of: fps, knots, ...
+ */
+func (err *ParseError) Error() string {
+	message := fmt.Sprintf("Invalid %s %q", err.ValueType, err.Value)
+	if len(err.Flag) > 0 {
+		message += " for --" + err.Flag
+	}
+
+	if err.NotPositive && err.ValueType == VALUE_TYPE_TEMPERATURE {
+		return message + ". It must be above absolute zero"
+	} else if err.NotPositive {
+		return message + ". It must be greater than zero"
+	}
+
+	if err.NoUnits {
+		message += fmt.Sprintf(". %q has no units, every term after the first needs them", err.Token)
+	} else if err.Units {
+		message += fmt.Sprintf(". Unknown units %q", err.Token)
+	} else if err.Token != err.Value {
+		message += fmt.Sprintf(". %q is not a number", err.Token)
+	}
+
+	if len(err.Suggestions) > 0 {
+		message += ", did you mean " + strings.Join(err.Suggestions, " or ") + "?"
+	} else {
+		message += "."
+	}
+
+	if err.Units && err.Token == err.Value {
+		return message + " Expected one of: " + strings.Join(err.Suffixes, ", ")
+	}
+
+	return message + " Expected a number optionally followed by one of: " + strings.Join(err.Suffixes, ", ")
+}
+
+
+/** Returns the accepted suffixes within two edits of the unknown suffix ignoring case, closest first */
+func suggestSuffixes(suffix string, suffixes []string) (suggestions []string) {
+	distances := make(map[string]int)
+	for _, candidate := range suffixes {
+		distance := editDistance(strings.ToLower(suffix), strings.ToLower(candidate))
+		if distance <= 2 && distance < len([]rune(suffix)) {
+			distances[candidate] = distance
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return distances[suggestions[i]] < distances[suggestions[j]]
+	})
+	if len(suggestions) > 3 {
+		suggestions = suggestions[:3]
+	}
+
+	return suggestions
+}
+
+
+/** Returns the Levenshtein distance between the strings */
+func editDistance(a, b string) int {
+	a_runes := []rune(a)
+	b_runes := []rune(b)
+
+	previous := make([]int, len(b_runes) + 1)
+	current := make([]int, len(b_runes) + 1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a_runes); i++ {
+		current[0] = i
+		for j := 1; j <= len(b_runes); j++ {
+			cost := 1
+			if a_runes[i - 1] == b_runes[j - 1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j] + 1, current[j - 1] + 1), previous[j - 1] + cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b_runes)]
+}
+
+
+/** Returns the smaller of the integers */
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+
+
+/**
+ * Returns the units of the value type for a suffix given on its own
+ *
+ * Unlike ParseValue the units are not recorded as input units. Returns a
+ * *ParseError if the units are not known for the value type.
+ */
+func ParseUnits(suffix, value_type string) (unit Unit, err error) {
+	suffix = strings.TrimSpace(suffix)
+
+	unit, found := LookupUnit(value_type, suffix)
+	if ! found || len(suffix) == 0 {
+		suffixes := UnitSuffixes(value_type)
+		return unit, &ParseError{Suffixes: suffixes, Suggestions: suggestSuffixes(suffix, suffixes), Token: suffix, Units: true, Value: suffix, ValueType: value_type}
+	}
+
+	return unit, nil
+}
+
+
+/**
+ * Parse user input value and normalize it for internal use
+ *
+ * The value may be a compound of terms in different units such as 5ft3in,
+ * 5' 3" or 1lb 4oz which are added together, and terms may be added or
+ * subtracted as in 300gr+10gr or 28in-1.5in. Numbers may have an exponent as
+ * in 2.5e3fps. The first term sets the input units and the user value is the
+ * total in its units. Terms after the first are differences so 59F+5F is 64F.
+ * A leading sign applies to the whole compound so -5ft3in is -5.25ft.
+ *
+ * Returns a *ParseError if a term is not a number with units known for the
+ * value type, or if the total is not greater than zero for a value type that
+ * can not be negative such as a weight.
+ */
+func ParseValue(value, value_type string) (parsed_data ParsedData, err error) {
 	// log.Printf("ParseValue()  <| value: %s | value_type: %s", value, value_type)
 
+	value = strings.TrimSpace(value)
 	if len(value) > 0 {
-		value_match := VALUE_RE.FindStringSubmatch(value)
-
-		number, _ := strconv.ParseFloat(value_match[1], 64)
-		suffix := strings.ToLower(value_match[2])
-
-		var designation string
-		var norm_type string
-		var norm_value float64
-
-		switch value_type {
-		case VALUE_TYPE_ANGLE:
-			norm_type = "degrees"
-
-			switch suffix {
-			case "degrees", "degree", "deg", "d":
-				norm_value = number * 1.0
-				designation = ANGLE_LABEL_DEGREES
-				// InputData.Metric = false
-			case "radians", "radian", "rad", "r":
-				norm_value = number * 1.0
-				designation = ANGLE_LABEL_RADIANS
-				// InputData.Metric = false
+		parse_err := &ParseError{Suffixes: UnitSuffixes(value_type), Token: value, Value: value, ValueType: value_type}
+
+		var input_units InputUnits
+		var negative bool
+		var norm_total float64
+
+		remaining := value
+		for term := 0; len(remaining) > 0; term++ {
+			term_match := VALUE_TERM_RE.FindStringSubmatch(remaining)
+			if term_match == nil {
+				parse_err.Token = remaining
+				return parsed_data, parse_err
+			}
+			remaining = remaining[len(term_match[0]):]
+
+			// The sign of the first term is applied to the total
+			if term == 0 && term_match[1] == "-" {
+				negative = true
+				term_match[1] = ""
 			}
 
-			InputData.Angle = designation
-		case VALUE_TYPE_LENGTH:
-			norm_type = "meter"
-
-			switch suffix {
-			case "feet", "foot", "ft", "f":
-				norm_value = number * LENGTH_FROM_FEET_TO_METERS
-				designation = LENGTH_LABEL_FOOT
-				InputData.Metric = false
-			case "inches", "inch", "in", "i":
-				norm_value = number * LENGTH_FROM_INCHES_TO_METERS
-				designation = LENGTH_LABEL_INCH
-				InputData.Metric = false
-			case "nmi", "nm", "M":
-				// Actual Values: M, NM, Nm, nm, nmi
-				norm_value = number * LENGTH_FROM_NAUTICAL_MILES_TO_METERS
-				designation = LENGTH_LABEL_NAUTICAL_MILE
-			case "yards", "yard", "yrd", "yd", "y":
-				norm_value = number * LENGTH_FROM_YARDS_TO_METERS
-				designation = LENGTH_LABEL_YARD
-				InputData.Metric = false
-			case "kilometers", "kilometer", "kilo", "km", "k":
-				norm_value = number * LENGTH_FROM_KILOMETERS_TO_METERS
-				designation = LENGTH_LABEL_KILOMETER
-				InputData.Metric = true
-			case "meters", "m", "":
-				norm_value = number
-				designation = LENGTH_LABEL_METER
-				InputData.Metric = true
-			case "centimeters", "centimeter", "centi", "cm", "c":
-				norm_value = number * LENGTH_FROM_CENTIMETERS_TO_METERS
-				designation = LENGTH_LABEL_CENTIMETER
-				InputData.Metric = true
-			case "millimeters", "millimeter", "milli", "mm":
-				norm_value = number * LENGTH_FROM_MILLIMETERS_TO_METERS
-				designation = LENGTH_LABEL_MILLIMETER
-				InputData.Metric = true
+			number, number_err := strconv.ParseFloat(term_match[1] + term_match[2], 64)
+			if number_err != nil {
+				parse_err.Token = term_match[2]
+				return parsed_data, parse_err
+			}
+			suffix := term_match[3]
+			if term > 0 && len(suffix) == 0 {
+				parse_err.NoUnits = true
+				parse_err.Token = strings.TrimSpace(term_match[0])
+				return parsed_data, parse_err
 			}
 
-			InputData.Length = designation
-		case VALUE_TYPE_MASS:
-			norm_type = "kilogram"
-
-			switch suffix {
-			case "grams", "g", "":
-				norm_value = number * MASS_FROM_GRAMS_TO_KILOGRAMS
-				designation = MASS_LABEL_GRAMS
-				InputData.Metric = true
-			case "grains", "gr":
-				norm_value = number * MASS_FROM_GRAINS_TO_KILOGRAMS
-				designation = MASS_LABEL_GRAINS
-				InputData.Metric = false
-			case "pounds", "#", "lb", "lbs":
-				norm_value = number * MASS_FROM_POUNDS_TO_KILOGRAMS
-				designation = MASS_LABEL_POUNDS
-				InputData.Metric = false
-			case "stone", "st":
-				norm_value = number * MASS_FROM_STONE_TO_KILOGRAMS
-				designation = MASS_LABEL_STONE
-				InputData.Metric = false
-			case "ton":
-				norm_value = number * MASS_FROM_TONS_SHORT_TO_KILOGRAMS
-				designation = MASS_LABEL_SHORT_TON
-				InputData.Metric = false
-			case "lt":
-				norm_value = number * MASS_FROM_TONS_LONG_TO_KILOGRAMS
-				designation = MASS_LABEL_LONG_TON
-				InputData.Metric = false
-			case "mt":
-				norm_value = number * MASS_FROM_TONS_METRIC_TO_KILOGRAMS
-				designation = MASS_LABEL_METRIC_TONNE
-				InputData.Metric = true
+			norm_value, designation, norm_type := convertValue(number, suffix, value_type)
+			if len(designation) == 0 {
+				parse_err.Token = term_match[3]
+				parse_err.Units = true
+				parse_err.Suggestions = suggestSuffixes(suffix, parse_err.Suffixes)
+				return parsed_data, parse_err
 			}
 
-			InputData.Mass = designation
-		case VALUE_TYPE_VELOCITY:
-			norm_type = "meters per second"
-
-			switch suffix {
-			case "fps":
-				norm_value = number * VELOCITY_FROM_FPS_TO_MPS
-				designation = VELOCITY_LABEL_FPS
-				InputData.Metric = false
-			case "knots", "knot", "kn", "kt":
-				norm_value = number * VELOCITY_FROM_KNOTS_TO_MPS
-				designation = VELOCITY_LABEL_KNOTS
-			case "kmph", "k":
-				norm_value = number * VELOCITY_FROM_KMPH_TO_MPS
-				designation = VELOCITY_LABEL_KMPH
-				InputData.Metric = true
-			case "mph":
-				norm_value = number * VELOCITY_FROM_MPH_TO_MPS
-				designation = VELOCITY_LABEL_MPH
-				InputData.Metric = false
-			case "mps", "":
-				norm_value = number
-				designation = VELOCITY_LABEL_MPS
-				InputData.Metric = true
+			if term == 0 {
+				input_units = InputData
+				parsed_data.Label = norm_type
+				parsed_data.UserLabel = designation
+			} else {
+				norm_zero, _, _ := convertValue(0, suffix, value_type)
+				norm_value -= norm_zero
 			}
+			norm_total += norm_value
 
-			InputData.Velocity = designation
+			if output_debug {
+				suffix_designation := suffix + " (" + designation + ")"
+				log.Printf("ParseValue()    | %8s: %12.6f %-20s | %12.6f %s", value_type, number, suffix_designation, norm_value, norm_type)
+			}
 		}
 
-		if output_debug {
-			// log.Printf("ParseValue()   <| value_match: %s", value_match)
-			// log.Printf("ParseValue()   <|      number: %f", number)
-			// log.Printf("ParseValue()   <|      suffix: %s", suffix)
-			// log.Printf("ParseValue()    | designation: %s", designation)
-			// log.Printf("ParseValue()    |  norm_value: %f", norm_value)
-			// log.Printf("ParseValue()    |   norm_type: %s", norm_type)
+		first_suffix := VALUE_TERM_RE.FindStringSubmatch(value)[3]
+		norm_zero, _, _ := convertValue(0, first_suffix, value_type)
+		norm_one, _, _ := convertValue(1, first_suffix, value_type)
+		InputData = input_units
 
-			// log.Printf("ParseValue()   <| %8s value: %17.6f %s (%s)", value_type, number, suffix, designation )
-			// log.Printf("ParseValue()    | %8s normilazed: %12.6f %s", value_type, norm_value, norm_type)
-
-			suffix_designation := suffix + " (" + designation + ")"
-			log.Printf("ParseValue()    | %8s: %12.6f %-20s | %12.6f %s", value_type, number, suffix_designation, norm_value, norm_type)
+		if negative {
+			norm_total = 2 * norm_zero - norm_total
+		}
+		if norm_total <= 0 && positiveValueType(value_type) {
+			parse_err.NotPositive = true
+			return parsed_data, parse_err
 		}
 
-		parsed_data.Label = norm_type
-		parsed_data.Value = norm_value
-		parsed_data.UserLabel = designation
-		parsed_data.UserValue = number
+		parsed_data.Value = norm_total
+		parsed_data.UserValue = (norm_total - norm_zero) / (norm_one - norm_zero)
+	}
+
+	return parsed_data, nil
+}
+
+
+/** Returns true if values of the type can not be zero or negative */
+func positiveValueType(value_type string) bool {
+	switch value_type {
+	case VALUE_TYPE_BALLISTIC_COEFFICIENT, VALUE_TYPE_ENERGY, VALUE_TYPE_FREQUENCY, VALUE_TYPE_LINEAR_DENSITY, VALUE_TYPE_MASS, VALUE_TYPE_MOMENTUM, VALUE_TYPE_PRESSURE, VALUE_TYPE_TEMPERATURE:
+		return true
+	}
+
+	return false
+}
+
+
+/** Parse the value as ParseValue does requiring it to be greater than zero if given */
+func ParsePositiveValue(value, value_type string) (parsed_data ParsedData, err error) {
+	parsed_data, err = ParseValue(value, value_type)
+	if err == nil && len(strings.TrimSpace(value)) > 0 && parsed_data.Value <= 0 {
+		value = strings.TrimSpace(value)
+		return parsed_data, &ParseError{NotPositive: true, Suffixes: UnitSuffixes(value_type), Token: value, Value: value, ValueType: value_type}
+	}
+
+	return parsed_data, err
+}
+
+
+/** Convert a number in the units of the suffix to the internal units of the value type. The designation is empty for unknown units. */
+func convertValue(number float64, suffix, value_type string) (norm_value float64, designation, norm_type string) {
+	group, _ := UnitGroupFor(value_type)
+	norm_type = group.Label
+
+	unit, found := LookupUnit(value_type, suffix)
+	if ! found {
+		return norm_value, designation, norm_type
+	}
+	norm_value = unit.Normalize(number)
+	designation = unit.Designation
+
+	switch unit.System {
+	case UNIT_SYSTEM_IMPERIAL:
+		InputData.Metric = false
+	case UNIT_SYSTEM_METRIC:
+		InputData.Metric = true
+	}
+
+	switch value_type {
+	case VALUE_TYPE_ANGLE:
+		InputData.Angle = designation
+	case VALUE_TYPE_ENERGY:
+		InputData.Energy = designation
+	case VALUE_TYPE_LENGTH:
+		InputData.Length = designation
+	case VALUE_TYPE_MASS:
+		InputData.Mass = designation
+	case VALUE_TYPE_MOMENTUM:
+		InputData.Momentum = designation
+	case VALUE_TYPE_PRESSURE:
+		InputData.Pressure = designation
+	case VALUE_TYPE_TEMPERATURE:
+		InputData.Temperature = designation
+	case VALUE_TYPE_VELOCITY:
+		InputData.Velocity = designation
 	}
 
-	return parsed_data
+	return norm_value, designation, norm_type
 }
 
 
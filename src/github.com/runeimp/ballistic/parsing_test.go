@@ -0,0 +1,203 @@
+/**
+ * Ballistic.parsing tests
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"strings"
+	"testing"
+)
+
+
+//
+// FUNCTIONS
+//
+
+func TestParseValueUnits(t *testing.T) {
+	tests := []struct {
+		value string
+		value_type string
+		user_label string
+		norm_value float64
+	}{
+		{"1m", VALUE_TYPE_LENGTH, LENGTH_LABEL_METER, 1},
+		{"1M", VALUE_TYPE_LENGTH, LENGTH_LABEL_NAUTICAL_MILE, LENGTH_FROM_NAUTICAL_MILES_TO_METERS},
+		{"2Meters", VALUE_TYPE_LENGTH, LENGTH_LABEL_METER, 2},
+		{"2METERS", VALUE_TYPE_LENGTH, LENGTH_LABEL_METER, 2},
+		{"1k", VALUE_TYPE_LENGTH, LENGTH_LABEL_KILOMETER, LENGTH_FROM_KILOMETERS_TO_METERS},
+		{"1k", VALUE_TYPE_VELOCITY, VELOCITY_LABEL_KMPH, VELOCITY_FROM_KMPH_TO_MPS},
+		{"1t", VALUE_TYPE_MASS, MASS_LABEL_METRIC_TONNE, MASS_FROM_TONS_METRIC_TO_KILOGRAMS},
+		{"1ton", VALUE_TYPE_MASS, MASS_LABEL_SHORT_TON, MASS_FROM_TONS_SHORT_TO_KILOGRAMS},
+		{"1 Ton", VALUE_TYPE_MASS, MASS_LABEL_SHORT_TON, MASS_FROM_TONS_SHORT_TO_KILOGRAMS},
+		{"100", VALUE_TYPE_LENGTH, LENGTH_LABEL_METER, 100},
+	}
+
+	for _, test := range tests {
+		parsed_data, err := ParseValue(test.value, test.value_type)
+		if err != nil {
+			t.Errorf("ParseValue(%q, %q) returned error: %v", test.value, test.value_type, err)
+			continue
+		}
+		if parsed_data.UserLabel != test.user_label {
+			t.Errorf("ParseValue(%q, %q) units = %q, expected %q", test.value, test.value_type, parsed_data.UserLabel, test.user_label)
+		}
+		if ! closeTo(parsed_data.Value, test.norm_value, 1e-6) {
+			t.Errorf("ParseValue(%q, %q) = %g, expected %g", test.value, test.value_type, parsed_data.Value, test.norm_value)
+		}
+	}
+}
+
+
+func TestParseValueCompound(t *testing.T) {
+	tests := []struct {
+		value string
+		value_type string
+		user_label string
+		user_value float64
+		norm_value float64
+	}{
+		{"5ft3in", VALUE_TYPE_LENGTH, LENGTH_LABEL_FOOT, 5.25, 5.25 * LENGTH_FROM_FEET_TO_METERS},
+		{"5' 3\"", VALUE_TYPE_LENGTH, LENGTH_LABEL_FOOT, 5.25, 5.25 * LENGTH_FROM_FEET_TO_METERS},
+		{"1lb 4oz", VALUE_TYPE_MASS, MASS_LABEL_POUNDS, 1.25, MASS_FROM_POUNDS_TO_KILOGRAMS + 4 * MASS_FROM_OUNCES_TO_KILOGRAMS},
+		{"2.5e3fps", VALUE_TYPE_VELOCITY, VELOCITY_LABEL_FPS, 2500, 2500 * VELOCITY_FROM_FPS_TO_MPS},
+		{"-3.5mm", VALUE_TYPE_LENGTH, LENGTH_LABEL_MILLIMETER, -3.5, -3.5 * LENGTH_FROM_MILLIMETERS_TO_METERS},
+		{"-5ft3in", VALUE_TYPE_LENGTH, LENGTH_LABEL_FOOT, -5.25, -5.25 * LENGTH_FROM_FEET_TO_METERS},
+		{"-5C", VALUE_TYPE_TEMPERATURE, TEMPERATURE_LABEL_CELSIUS, -5, TEMPERATURE_FROM_CELSIUS_TO_KELVIN - 5},
+		{"300gr+10gr", VALUE_TYPE_MASS, MASS_LABEL_GRAINS, 310, 310 * MASS_FROM_GRAINS_TO_KILOGRAMS},
+		{"28in-1.5in", VALUE_TYPE_LENGTH, LENGTH_LABEL_INCH, 26.5, 26.5 * LENGTH_FROM_INCHES_TO_METERS},
+		{"59F+5F", VALUE_TYPE_TEMPERATURE, TEMPERATURE_LABEL_FAHRENHEIT, 64, (64 - 32) * TEMPERATURE_FROM_FAHRENHEIT_TO_CELSIUS + TEMPERATURE_FROM_CELSIUS_TO_KELVIN},
+	}
+
+	for _, test := range tests {
+		parsed_data, err := ParseValue(test.value, test.value_type)
+		if err != nil {
+			t.Errorf("ParseValue(%q, %q) returned error: %v", test.value, test.value_type, err)
+			continue
+		}
+		if parsed_data.UserLabel != test.user_label {
+			t.Errorf("ParseValue(%q, %q) units = %q, expected %q", test.value, test.value_type, parsed_data.UserLabel, test.user_label)
+		}
+		if ! closeTo(parsed_data.UserValue, test.user_value, 1e-6) {
+			t.Errorf("ParseValue(%q, %q) user value = %g, expected %g", test.value, test.value_type, parsed_data.UserValue, test.user_value)
+		}
+		if ! closeTo(parsed_data.Value, test.norm_value, 1e-6) {
+			t.Errorf("ParseValue(%q, %q) = %g, expected %g", test.value, test.value_type, parsed_data.Value, test.norm_value)
+		}
+	}
+}
+
+
+func TestParseValueErrors(t *testing.T) {
+	tests := []struct {
+		value string
+		value_type string
+		token string
+		no_units bool
+		units bool
+		suggestion string
+	}{
+		{"5ft3", VALUE_TYPE_LENGTH, "3", true, false, ""},
+		{"1lb 4", VALUE_TYPE_MASS, "4", true, false, ""},
+		{"900fs", VALUE_TYPE_VELOCITY, "fs", false, true, "fps"},
+		{"100yds", VALUE_TYPE_LENGTH, "yds", false, true, "yd"},
+		{"10 Metres", VALUE_TYPE_VELOCITY, "Metres", false, true, ""},
+		{"abc", VALUE_TYPE_LENGTH, "abc", false, false, ""},
+	}
+
+	for _, test := range tests {
+		_, err := ParseValue(test.value, test.value_type)
+		parse_err, ok := err.(*ParseError)
+		if ! ok {
+			t.Errorf("ParseValue(%q, %q) error = %v, expected a *ParseError", test.value, test.value_type, err)
+			continue
+		}
+		if parse_err.Token != test.token || parse_err.NoUnits != test.no_units || parse_err.Units != test.units {
+			t.Errorf("ParseValue(%q, %q) error = %+v, expected token %q, no units %t and units %t", test.value, test.value_type, parse_err, test.token, test.no_units, test.units)
+		}
+		if len(test.suggestion) > 0 && ! strings.Contains(parse_err.Error(), "did you mean " + test.suggestion) {
+			t.Errorf("ParseValue(%q, %q) error %q does not suggest %q", test.value, test.value_type, parse_err.Error(), test.suggestion)
+		}
+	}
+}
+
+
+func TestParseValueNotPositive(t *testing.T) {
+	tests := []struct {
+		value string
+		value_type string
+	}{
+		{"10g-20g", VALUE_TYPE_MASS},
+		{"-5gr", VALUE_TYPE_MASS},
+		{"0J", VALUE_TYPE_ENERGY},
+		{"-500F", VALUE_TYPE_TEMPERATURE},
+	}
+
+	for _, test := range tests {
+		_, err := ParseValue(test.value, test.value_type)
+		if parse_err, ok := err.(*ParseError); ! ok || ! parse_err.NotPositive {
+			t.Errorf("ParseValue(%q, %q) error = %v, expected it not to be positive", test.value, test.value_type, err)
+		}
+	}
+}
+
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		suffix string
+		value_type string
+		designation string
+	}{
+		{"m", VALUE_TYPE_LENGTH, LENGTH_LABEL_METER},
+		{"M", VALUE_TYPE_LENGTH, LENGTH_LABEL_NAUTICAL_MILE},
+		{"METERS", VALUE_TYPE_LENGTH, LENGTH_LABEL_METER},
+		{"k", VALUE_TYPE_VELOCITY, VELOCITY_LABEL_KMPH},
+		{"t", VALUE_TYPE_MASS, MASS_LABEL_METRIC_TONNE},
+		{"ton", VALUE_TYPE_MASS, MASS_LABEL_SHORT_TON},
+	}
+
+	for _, test := range tests {
+		unit, err := ParseUnits(test.suffix, test.value_type)
+		if err != nil {
+			t.Errorf("ParseUnits(%q, %q) returned error: %v", test.suffix, test.value_type, err)
+			continue
+		}
+		if unit.Designation != test.designation {
+			t.Errorf("ParseUnits(%q, %q) = %q, expected %q", test.suffix, test.value_type, unit.Designation, test.designation)
+		}
+	}
+
+	if _, err := ParseUnits("", VALUE_TYPE_LENGTH); err == nil {
+		t.Errorf("ParseUnits(%q, %q) expected an error", "", VALUE_TYPE_LENGTH)
+	}
+}
+
+
+func TestParsePositiveValue(t *testing.T) {
+	tests := []struct {
+		value string
+		value_type string
+		not_positive bool
+	}{
+		{"100yd", VALUE_TYPE_LENGTH, false},
+		{"", VALUE_TYPE_LENGTH, false},
+		{"0yd", VALUE_TYPE_LENGTH, true},
+		{"-10fps", VALUE_TYPE_VELOCITY, true},
+		{"28in-30in", VALUE_TYPE_LENGTH, true},
+	}
+
+	for _, test := range tests {
+		_, err := ParsePositiveValue(test.value, test.value_type)
+		parse_err, ok := err.(*ParseError)
+		if test.not_positive != (ok && parse_err.NotPositive) {
+			t.Errorf("ParsePositiveValue(%q, %q) error = %v, expected not positive %t", test.value, test.value_type, err, test.not_positive)
+		}
+	}
+}
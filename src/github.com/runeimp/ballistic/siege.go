@@ -0,0 +1,194 @@
+/**
+ * Ballistic.siege
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+
+//
+// Structs
+//
+
+/**
+ * Counterweight trebuchet or torsion/tension catapult
+ *
+ * A trebuchet stores energy in the raised counterweight. Catapults store it in
+ * a twisted skein (torsion) or a bent bow (tension) wound back by the draw
+ * weight over the draw length.
+ */
+type SiegeEngine struct {
+	Counterweight float64 // Kilograms (trebuchet)
+	Delivered bool        // Efficiency is the fraction delivered to the projectile as given, not limited by the arms
+	DrawLength float64    // Meters the arm is wound back (catapult)
+	DrawWeight float64    // Newtons at full draw (catapult)
+	Drop float64          // Meters the counterweight falls to release. Estimated from the short arm when not set.
+	Efficiency float64    // Fraction of the stored energy not lost to the arm, friction and the sling
+	LongArm float64       // Meters from the pivot to the end of the throwing arm
+	ReleaseHeight float64 // Meters above the ground at release. Estimated from the long arm when not set.
+	ShortArm float64      // Meters from the pivot to the counterweight (trebuchet)
+	Sling float64         // Meters from the end of the throwing arm to the pouch (trebuchet)
+	Type string
+}
+
+
+//
+// CONSTANTS
+//
+const SIEGE_TYPE_TENSION = "tension"
+const SIEGE_TYPE_TORSION = "torsion"
+const SIEGE_TYPE_TREBUCHET = "trebuchet"
+
+const TREBUCHET_START_ANGLE float64 = math.Pi / 4 // radians the throwing arm rests below horizontal with its end on the ground
+
+
+//
+// VARIABLES
+//
+var SiegeEngines map[string]SiegeEngine = map[string]SiegeEngine{
+	SIEGE_TYPE_TENSION:   SiegeEngine{Efficiency: 0.40, Type: SIEGE_TYPE_TENSION},
+	SIEGE_TYPE_TORSION:   SiegeEngine{Efficiency: 0.30, Type: SIEGE_TYPE_TORSION},
+	SIEGE_TYPE_TREBUCHET: SiegeEngine{Efficiency: 0.70, Type: SIEGE_TYPE_TREBUCHET},
+}
+
+
+//
+// FUNCTIONS
+//
+
+/** Returns a typical siege engine of the type (trebuchet, torsion or tension) */
+func SiegeEngineModel(name string) (engine SiegeEngine, found bool) {
+	engine, found = SiegeEngines[strings.ToLower(name)]
+	return engine, found
+}
+
+
+/** Returns the sorted list of siege engine types */
+func SiegeEngineNames() (names []string) {
+	for name := range SiegeEngines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+
+/**
+ * Calculate the meters the counterweight falls from cocked to release
+ *
+ * The throwing arm rests 45° below horizontal so the counterweight starts
+ * 45° above it and falls to hang straight below the pivot.
+ */
+func (engine SiegeEngine) DropHeight() float64 {
+	if engine.Drop > 0 {
+		return engine.Drop
+	}
+
+	return engine.ShortArm * (1 + math.Sin(TREBUCHET_START_ANGLE))
+}
+
+
+/** Calculate the energy in joules stored in the counterweight or the wound arm */
+func (engine SiegeEngine) StoredEnergy() float64 {
+	if engine.Type == SIEGE_TYPE_TREBUCHET {
+		return engine.Counterweight * GRAVITY_MPS * engine.DropHeight()
+	}
+
+	return 0.5 * engine.DrawWeight * engine.DrawLength
+}
+
+
+/**
+ * Calculate the fraction of the stored energy delivered to a projectile of the given mass in kilograms
+ *
+ * A well matched trebuchet leaves little energy in the counterweight at
+ * release. A light projectile leaves more as it can move no faster than
+ * (long arm + sling) / short arm times the counterweight falling freely
+ * through the drop.
+ */
+func (engine SiegeEngine) EfficiencyFor(mass float64) float64 {
+	if mass <= 0 {
+		return 0.0
+	}
+	stored := engine.StoredEnergy()
+	if engine.Delivered || engine.Type != SIEGE_TYPE_TREBUCHET || engine.ShortArm <= 0 || stored <= 0 {
+		return engine.Efficiency
+	}
+
+	ratio := (engine.LongArm + engine.Sling) / engine.ShortArm
+	velocity := ratio * math.Sqrt(2 * GRAVITY_MPS * engine.DropHeight())
+
+	return math.Min(engine.Efficiency, 0.5 * mass * velocity * velocity / stored)
+}
+
+
+/** Calculate the energy in joules delivered to a projectile of the given mass in kilograms */
+func (engine SiegeEngine) DeliveredEnergy(mass float64) float64 {
+	return engine.EfficiencyFor(mass) * engine.StoredEnergy()
+}
+
+
+/** Calculate the velocity in meters per second of a projectile of the given mass in kilograms */
+func (engine SiegeEngine) ReleaseVelocity(mass float64) float64 {
+	if mass <= 0 {
+		return 0.0
+	}
+
+	return math.Sqrt(2 * engine.DeliveredEnergy(mass) / mass)
+}
+
+
+/**
+ * Calculate the meters above the ground the projectile is released at
+ *
+ * The throwing arm releases near upright. A trebuchet pivot is high enough for
+ * the end of the arm to rest on the ground and a catapult pivot sits on the
+ * ground frame.
+ */
+func (engine SiegeEngine) Height() float64 {
+	if engine.ReleaseHeight > 0 {
+		return engine.ReleaseHeight
+	}
+	if engine.Type == SIEGE_TYPE_TREBUCHET {
+		return engine.LongArm * (1 + math.Sin(TREBUCHET_START_ANGLE))
+	}
+
+	return engine.LongArm
+}
+
+
+/**
+ * Calculate the release angle in degrees for the longest range in a vacuum at the velocity in meters per second
+ *
+ * Released above the ground the best angle is below 45°:
+ * atan(v / sqrt(v² + 2 g h)).
+ */
+func (engine SiegeEngine) ReleaseAngle(velocity float64) float64 {
+	if velocity <= 0 {
+		return 0.0
+	}
+
+	drop := 2 * GRAVITY_MPS * engine.Height()
+
+	return math.Atan(velocity / math.Sqrt(velocity * velocity + drop)) / ANGLE_DEGREES_TO_RADIANS
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
@@ -0,0 +1,211 @@
+/**
+ * Ballistic.sling
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+
+//
+// Structs
+//
+
+/** Elastic band material with the secant modulus at typical working stretch */
+type BandMaterial struct {
+	Density float64    // Kilograms per cubic meter
+	Hysteresis float64 // Fraction of the stored energy lost as heat in the band on release
+	Modulus float64    // Pascals
+	Name string
+}
+
+
+/**
+ * Slingshot (catapult) with a pair of flat tapered bands
+ *
+ * Each band is a strip of rubber tapering from the fork end to the pouch end.
+ * The narrow pouch end stretches more than the wide fork end under the same
+ * pull.
+ */
+type Slingshot struct {
+	Bands int             // Number of bands pulling the pouch
+	DrawLength float64    // Meters from the fork to the pouch at full draw
+	Hysteresis float64    // Fraction of the stored energy lost as heat in the bands on release
+	Length float64        // Meters of active band from the fork to the pouch at rest
+	Material BandMaterial
+	Pouch float64         // Kilograms
+	Taper float64         // Pouch end width as a fraction of the fork end width
+	Thickness float64     // Meters
+	Width float64         // Meters at the fork end
+}
+
+
+/** Rotary sling released from the end of the cord */
+type Sling struct {
+	Length float64      // Meters from the hand to the pouch
+	Revolutions float64 // Revolutions per second at release
+}
+
+
+//
+// CONSTANTS
+//
+const SLINGSHOT_BANDS int = 2
+const SLINGSHOT_LENGTH float64 = 0.18       // meters
+const SLINGSHOT_POUCH float64 = 0.002       // kilograms
+const SLINGSHOT_TAPER float64 = 0.80
+const SLINGSHOT_THICKNESS float64 = 0.0007  // meters
+const SLINGSHOT_WIDTH float64 = 0.025       // meters
+
+
+//
+// VARIABLES
+//
+var BandMaterials map[string]BandMaterial = map[string]BandMaterial{
+	"latex": BandMaterial{Density: 950, Hysteresis: 0.15, Modulus: 600000, Name: "latex"},
+	"gum":   BandMaterial{Density: 1100, Hysteresis: 0.25, Modulus: 500000, Name: "gum"},
+	"tpe":   BandMaterial{Density: 900, Hysteresis: 0.35, Modulus: 700000, Name: "tpe"},
+}
+
+
+//
+// FUNCTIONS
+//
+
+/** Returns a typical slingshot with bands of the material (latex, gum or tpe) */
+func SlingshotModel(material string) (slingshot Slingshot, found bool) {
+	slingshot.Material, found = BandMaterials[strings.ToLower(material)]
+	if ! found {
+		return slingshot, found
+	}
+
+	slingshot.Bands = SLINGSHOT_BANDS
+	slingshot.Hysteresis = slingshot.Material.Hysteresis
+	slingshot.Length = SLINGSHOT_LENGTH
+	slingshot.Pouch = SLINGSHOT_POUCH
+	slingshot.Taper = SLINGSHOT_TAPER
+	slingshot.Thickness = SLINGSHOT_THICKNESS
+	slingshot.Width = SLINGSHOT_WIDTH
+
+	return slingshot, found
+}
+
+
+/** Returns the sorted list of band materials */
+func BandMaterialNames() (names []string) {
+	for name := range BandMaterials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+
+/**
+ * Calculate the stiffness in newtons per meter of one band
+ *
+ * The band is a series of thin slices each stretching under the same force
+ * so the stiffness of a band tapering linearly from W1 to W2 over length L is
+ * E t (W1 - W2) / (L ln(W1 / W2)).
+ */
+func (slingshot Slingshot) Stiffness() float64 {
+	fork := slingshot.Width
+	pouch := slingshot.Width * slingshot.Taper
+	sheet := slingshot.Material.Modulus * slingshot.Thickness // newtons per meter of width
+
+	if slingshot.Length <= 0 {
+		return 0.0
+	}
+	if slingshot.Taper == 1 {
+		return sheet * fork / slingshot.Length
+	}
+
+	return sheet * (fork - pouch) / (slingshot.Length * math.Log(fork / pouch))
+}
+
+
+/** Calculate the meters each band is stretched at full draw */
+func (slingshot Slingshot) Stretch() float64 {
+	return math.Max(slingshot.DrawLength - slingshot.Length, 0)
+}
+
+
+/** Calculate the draw force in newtons at full draw */
+func (slingshot Slingshot) DrawForce() float64 {
+	return float64(slingshot.Bands) * slingshot.Stiffness() * slingshot.Stretch()
+}
+
+
+/** Calculate the energy in joules stored in the bands at full draw */
+func (slingshot Slingshot) StoredEnergy() float64 {
+	stretch := slingshot.Stretch()
+	return float64(slingshot.Bands) * 0.5 * slingshot.Stiffness() * stretch * stretch
+}
+
+
+/** Calculate the kilograms of all the bands */
+func (slingshot Slingshot) BandMass() float64 {
+	width := slingshot.Width * (1 + slingshot.Taper) * 0.5
+	volume := width * slingshot.Thickness * slingshot.Length
+
+	return float64(slingshot.Bands) * volume * slingshot.Material.Density
+}
+
+
+/**
+ * Calculate the fraction of the stored energy delivered to ammo of the given mass in kilograms
+ *
+ * Hysteresis is lost as heat in the bands and the rest is shared with the
+ * pouch and the bands. The bands move from still at the fork to full speed at
+ * the pouch moving with the ammo as if a third of their mass were added to it.
+ */
+func (slingshot Slingshot) EfficiencyFor(mass float64) float64 {
+	if mass <= 0 {
+		return 0.0
+	}
+
+	moving := mass + slingshot.Pouch + slingshot.BandMass() / 3
+
+	return (1 - slingshot.Hysteresis) * mass / moving
+}
+
+
+/** Calculate the energy in joules delivered to ammo of the given mass in kilograms */
+func (slingshot Slingshot) DeliveredEnergy(mass float64) float64 {
+	return slingshot.EfficiencyFor(mass) * slingshot.StoredEnergy()
+}
+
+
+/** Calculate the velocity in meters per second of ammo of the given mass in kilograms */
+func (slingshot Slingshot) ReleaseVelocity(mass float64) float64 {
+	if mass <= 0 {
+		return 0.0
+	}
+
+	return math.Sqrt(2 * slingshot.DeliveredEnergy(mass) / mass)
+}
+
+
+/** Calculate the velocity in meters per second of the stone at release as the speed of the end of the cord */
+func (sling Sling) ReleaseVelocity() float64 {
+	return 2 * math.Pi * sling.Revolutions * sling.Length
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
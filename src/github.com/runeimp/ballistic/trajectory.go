@@ -0,0 +1,419 @@
+/**
+ * Ballistic.trajectory
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"math"
+)
+
+
+//
+// Structs
+//
+
+/** Returns the drag coefficient of a projectile at the given Mach number */
+type DragFunction func(mach float64) float64
+
+type TrajectoryInput struct {
+	Angle float64                // Launch angle above the line of sight in radians
+	Atmosphere Atmosphere        // Air the projectile flies through. Sea level standard if not set.
+	BallisticCoefficient float64 // Ballistic coefficient in kilograms per square meter
+	Drag DragFunction            // Drag coefficient by Mach number. Flight is in a vacuum if nil.
+	Mass float64                 // Projectile mass in kilograms
+	SightHeight float64          // Height of the line of sight above the bore in meters
+	TimeStep float64             // Integration time step in seconds
+	Velocity float64             // Muzzle velocity in meters per second
+	Winds []Wind                 // Wind zones ordered by distance from the muzzle
+
+	air_density float64
+	speed_of_sound float64
+}
+
+type TrajectoryPoint struct {
+	Distance float64 // Horizontal distance from the muzzle in meters
+	Drift float64    // Lateral distance from the line of sight in meters, positive to the right
+	Energy float64   // Kinetic energy in joules
+	Height float64   // Height above the line of sight in meters
+	Momentum float64 // Momentum in kilogram meters per second
+	Time float64     // Time of flight in seconds
+	Velocity float64 // Speed in meters per second
+}
+
+type ZeroData struct {
+	Angle float64              // Bore angle above the line of sight in radians
+	Apex TrajectoryPoint       // Highest point above the line of sight
+	FarZero TrajectoryPoint    // Where the projectile falls back through the line of sight
+	NearZero TrajectoryPoint   // Where the projectile first rises through the line of sight
+	PointBlank TrajectoryPoint // Where the projectile leaves the target radius around the line of sight
+}
+
+type Wind struct {
+	Direction float64 // Direction the wind blows from in radians. 0 is a headwind, π/2 from the right.
+	Speed float64     // Meters per second
+	Until float64     // Distance from the muzzle in meters the wind blows until. 0 is all the way.
+}
+
+
+//
+// FUNCTIONS
+//
+
+/**
+ * Calculate the acceleration of the projectile for the given distance and velocity components
+ *
+ * Drag acts against the velocity of the projectile relative to the air so the
+ * wind only moves the projectile when there is drag.
+ */
+func (input TrajectoryInput) acceleration(x, vx, vy, vz float64) (ax, ay, az float64) {
+	ax = 0.0
+	ay = -GRAVITY_MPS
+	az = 0.0
+
+	if input.Drag != nil && input.BallisticCoefficient > 0 {
+		wind_x, wind_z := input.windAt(x)
+		air_vx := vx - wind_x
+		air_vz := vz - wind_z
+
+		speed := math.Sqrt(air_vx * air_vx + vy * vy + air_vz * air_vz)
+		mach := speed / input.speed_of_sound
+
+		// Drag deceleration is π⋅ρ⋅v²⋅Cd / 8⋅BC along the velocity vector
+		retardation := math.Pi * input.air_density * input.Drag(mach) * speed / (8 * input.BallisticCoefficient)
+		ax -= retardation * air_vx
+		ay -= retardation * vy
+		az -= retardation * air_vz
+	}
+
+	return ax, ay, az
+}
+
+
+/** Build a trajectory point from the integrator state */
+func (input TrajectoryInput) point(x, y, z, vx, vy, vz, t float64) (point TrajectoryPoint) {
+	point.Distance = x
+	point.Drift = z
+	point.Height = y - input.SightHeight
+	point.Time = t
+	point.Velocity = math.Sqrt(vx * vx + vy * vy + vz * vz)
+	point.Energy = input.Mass * point.Velocity * point.Velocity * 0.5
+	point.Momentum = input.Mass * point.Velocity
+
+	return point
+}
+
+
+/**
+ * Step the projectile through flight with a fourth order Runge-Kutta integrator
+ *
+ * The visit function is called with every point of the trajectory, starting
+ * at the muzzle, and integration stops as soon as it returns false, the
+ * projectile stops moving or TRAJECTORY_MAX_TIME is reached.
+ */
+func (input TrajectoryInput) Integrate(visit func(point TrajectoryPoint) bool) {
+	dt := input.TimeStep
+	if dt <= 0 {
+		dt = TRAJECTORY_TIME_STEP
+	}
+	half_dt := dt * 0.5
+
+	input.air_density = input.Atmosphere.Density()
+	input.speed_of_sound = input.Atmosphere.SpeedOfSound()
+
+	x, y, z, t := 0.0, 0.0, 0.0, 0.0
+	vx := input.Velocity * math.Cos(input.Angle)
+	vy := input.Velocity * math.Sin(input.Angle)
+	vz := 0.0
+
+	for t <= TRAJECTORY_MAX_TIME {
+		if ! visit(input.point(x, y, z, vx, vy, vz, t)) || (vx == 0 && vy == 0) {
+			return
+		}
+
+		ax1, ay1, az1 := input.acceleration(x, vx, vy, vz)
+		vx2, vy2, vz2 := vx + ax1 * half_dt, vy + ay1 * half_dt, vz + az1 * half_dt
+		ax2, ay2, az2 := input.acceleration(x + vx * half_dt, vx2, vy2, vz2)
+		vx3, vy3, vz3 := vx + ax2 * half_dt, vy + ay2 * half_dt, vz + az2 * half_dt
+		ax3, ay3, az3 := input.acceleration(x + vx2 * half_dt, vx3, vy3, vz3)
+		vx4, vy4, vz4 := vx + ax3 * dt, vy + ay3 * dt, vz + az3 * dt
+		ax4, ay4, az4 := input.acceleration(x + vx3 * dt, vx4, vy4, vz4)
+
+		x += (vx + 2 * vx2 + 2 * vx3 + vx4) * dt / 6
+		y += (vy + 2 * vy2 + 2 * vy3 + vy4) * dt / 6
+		z += (vz + 2 * vz2 + 2 * vz3 + vz4) * dt / 6
+		vx += (ax1 + 2 * ax2 + 2 * ax3 + ax4) * dt / 6
+		vy += (ay1 + 2 * ay2 + 2 * ay3 + ay4) * dt / 6
+		vz += (az1 + 2 * az2 + 2 * az3 + az4) * dt / 6
+		t += dt
+	}
+}
+
+
+/**
+ * Find the first point of the trajectory where the condition is met
+ *
+ * The returned point is linearly interpolated between the integration steps
+ * either side of the condition using the value function. Found is false if
+ * the projectile never meets the condition.
+ */
+func (input TrajectoryInput) Find(target float64, value func(point TrajectoryPoint) float64, condition func(previous, current TrajectoryPoint) bool) (found_point TrajectoryPoint, found bool) {
+	var previous TrajectoryPoint
+	first := true
+
+	input.Integrate(func(point TrajectoryPoint) bool {
+		if ! first && condition(previous, point) {
+			found_point = InterpolatePoints(previous, point, target, value)
+			found = true
+			return false
+		}
+		first = false
+		previous = point
+		return true
+	})
+
+	return found_point, found
+}
+
+
+/** Find the trajectory point at the given horizontal distance in meters */
+func (input TrajectoryInput) AtDistance(distance float64) (TrajectoryPoint, bool) {
+	return input.Find(distance, pointDistance, func(previous, current TrajectoryPoint) bool {
+		return current.Distance >= distance
+	})
+}
+
+
+/**
+ * Find the trajectory points at each of the horizontal distances in meters
+ *
+ * The distances must be in increasing order. Distances the projectile never
+ * reaches are left out.
+ */
+func (input TrajectoryInput) AtDistances(distances []float64) (points []TrajectoryPoint) {
+	var previous TrajectoryPoint
+	first := true
+	next := 0
+
+	input.Integrate(func(point TrajectoryPoint) bool {
+		for next < len(distances) && point.Distance >= distances[next] {
+			if first {
+				points = append(points, point)
+			} else {
+				points = append(points, InterpolatePoints(previous, point, distances[next], pointDistance))
+			}
+			next++
+		}
+		first = false
+		previous = point
+		return next < len(distances)
+	})
+
+	return points
+}
+
+
+/**
+ * Find the first point after the muzzle where the projectile falls through the given height in meters
+ *
+ * Found is false if the projectile never falls from above the height to it.
+ */
+func (input TrajectoryInput) AtDrop(height float64) (TrajectoryPoint, bool) {
+	return input.Find(height, pointHeight, func(previous, current TrajectoryPoint) bool {
+		return previous.Height > height && current.Height <= height
+	})
+}
+
+
+/**
+ * Find the bore angle that zeros the line of sight at the given distance in meters
+ *
+ * Found is false if the projectile can not reach the line of sight at that
+ * distance.
+ */
+func (input TrajectoryInput) ZeroAngle(distance float64) (angle float64, found bool) {
+	height := func(angle float64) float64 {
+		input.Angle = angle
+		point, found := input.AtDistance(distance)
+		if ! found {
+			return math.Inf(-1)
+		}
+		return point.Height
+	}
+
+	low := TRAJECTORY_ANGLE_MIN
+	high := TRAJECTORY_ANGLE_MAX
+	if height(high) < 0 {
+		return 0.0, false
+	}
+
+	for i := 0; i < 48; i++ {
+		angle = (low + high) * 0.5
+		if height(angle) < 0 {
+			low = angle
+		} else {
+			high = angle
+		}
+	}
+
+	return angle, true
+}
+
+
+/**
+ * Find the bore angle that gives the maximum point blank range for the target radius
+ *
+ * This is the angle where the apex of the trajectory is just the target
+ * radius above the line of sight.
+ */
+func (input TrajectoryInput) PointBlankAngle(radius float64) (angle float64) {
+	rises_above := func(angle float64) (above bool) {
+		var previous TrajectoryPoint
+		first := true
+
+		input.Angle = angle
+		input.Integrate(func(point TrajectoryPoint) bool {
+			if point.Height > radius {
+				above = true
+				return false
+			}
+			if ! first && point.Height < previous.Height {
+				return false
+			}
+			first = false
+			previous = point
+			return true
+		})
+
+		return above
+	}
+
+	low := TRAJECTORY_ANGLE_MIN
+	high := TRAJECTORY_ANGLE_MAX
+
+	for i := 0; i < 48; i++ {
+		angle = (low + high) * 0.5
+		if rises_above(angle) {
+			high = angle
+		} else {
+			low = angle
+		}
+	}
+
+	// The low angle never rises above the target radius
+	return low
+}
+
+
+/**
+ * Find the zeros, apex and point blank range of the trajectory for the target radius
+ *
+ * The point blank range is where the projectile first leaves the target radius
+ * around the line of sight, either rising above it or falling below it.
+ * Integration stops once the projectile falls below the target radius.
+ */
+func (input TrajectoryInput) Zero(radius float64) (zero ZeroData) {
+	var previous TrajectoryPoint
+	first := true
+	inside := false
+	risen := false
+
+	zero.Angle = input.Angle
+
+	input.Integrate(func(point TrajectoryPoint) bool {
+		if first {
+			first = false
+			previous = point
+			zero.Apex = point
+			inside = point.Height >= -radius
+			risen = point.Height >= 0
+			return true
+		}
+
+		if point.Height > zero.Apex.Height {
+			zero.Apex = point
+		}
+		if ! risen && point.Height >= 0 {
+			zero.NearZero = InterpolatePoints(previous, point, 0.0, pointHeight)
+			risen = true
+		} else if risen && zero.FarZero.Distance == 0 && previous.Height >= 0 && point.Height < 0 {
+			zero.FarZero = InterpolatePoints(previous, point, 0.0, pointHeight)
+		}
+
+		if zero.PointBlank.Distance == 0 {
+			if ! inside {
+				inside = point.Height >= -radius
+			} else if point.Height > radius {
+				zero.PointBlank = InterpolatePoints(previous, point, radius, pointHeight)
+			} else if point.Height < -radius {
+				zero.PointBlank = InterpolatePoints(previous, point, -radius, pointHeight)
+			}
+		}
+
+		if inside && point.Height < -radius && point.Height < previous.Height {
+			return false
+		}
+
+		previous = point
+		return true
+	})
+
+	return zero
+}
+
+
+/** Linearly interpolate between two trajectory points where value() equals target */
+func InterpolatePoints(a, b TrajectoryPoint, target float64, value func(point TrajectoryPoint) float64) (point TrajectoryPoint) {
+	fraction := 0.0
+	span := value(b) - value(a)
+	if span != 0 {
+		fraction = (target - value(a)) / span
+	}
+
+	point.Distance = a.Distance + (b.Distance - a.Distance) * fraction
+	point.Drift = a.Drift + (b.Drift - a.Drift) * fraction
+	point.Energy = a.Energy + (b.Energy - a.Energy) * fraction
+	point.Height = a.Height + (b.Height - a.Height) * fraction
+	point.Momentum = a.Momentum + (b.Momentum - a.Momentum) * fraction
+	point.Time = a.Time + (b.Time - a.Time) * fraction
+	point.Velocity = a.Velocity + (b.Velocity - a.Velocity) * fraction
+
+	return point
+}
+
+
+/** Returns the downrange and lateral components of the air velocity at the given distance */
+func (input TrajectoryInput) windAt(distance float64) (wind_x, wind_z float64) {
+	for _, wind := range input.Winds {
+		if wind.Until <= 0 || distance < wind.Until {
+			wind_x = -wind.Speed * math.Cos(wind.Direction)
+			wind_z = -wind.Speed * math.Sin(wind.Direction)
+			break
+		}
+	}
+
+	return wind_x, wind_z
+}
+
+
+func pointDistance(point TrajectoryPoint) float64 {
+	return point.Distance
+}
+
+func pointHeight(point TrajectoryPoint) float64 {
+	return point.Height
+}
+
+
+/** Initialize Package */
+func init() {
+	// Nada
+}
+
@@ -0,0 +1,113 @@
+/**
+ * Ballistic.trajectory tests
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"math"
+	"testing"
+)
+
+
+//
+// FUNCTIONS
+//
+
+/** Returns true if the value is within the relative tolerance of the expected value */
+func closeTo(value, expected, tolerance float64) bool {
+	return math.Abs(value - expected) <= tolerance * math.Max(1, math.Abs(expected))
+}
+
+
+func TestIntegrateVacuumRange(t *testing.T) {
+	tests := []struct {
+		velocity float64
+		degrees float64
+	}{
+		{100, 30},
+		{250, 45},
+		{50, 10},
+	}
+
+	for _, test := range tests {
+		angle := test.degrees * math.Pi / 180
+		input := TrajectoryInput{Angle: angle, Mass: 0.01, Velocity: test.velocity}
+		expected := test.velocity * test.velocity * math.Sin(2 * angle) / GRAVITY_MPS
+
+		point, found := input.AtDrop(0.0)
+		if ! found {
+			t.Errorf("AtDrop(0) at %g m/s and %g° not found", test.velocity, test.degrees)
+			continue
+		}
+		if ! closeTo(point.Distance, expected, 1e-4) {
+			t.Errorf("Range at %g m/s and %g° = %g m, expected v²⋅sin2θ/g = %g m", test.velocity, test.degrees, point.Distance, expected)
+		}
+	}
+}
+
+
+func TestAtDropNotFound(t *testing.T) {
+	input := TrajectoryInput{Angle: math.Pi / 6, Mass: 0.01, Velocity: 100}
+	apex := math.Pow(100 * math.Sin(math.Pi / 6), 2) / (2 * GRAVITY_MPS)
+
+	if point, found := input.AtDrop(apex + 1); found {
+		t.Errorf("AtDrop(%g) above the %g m apex found %+v", apex + 1, apex, point)
+	}
+	if point, found := input.AtDrop(-1e9); found {
+		t.Errorf("AtDrop(-1e9) beyond the flight time found %+v", point)
+	}
+}
+
+
+func TestZeroAngle(t *testing.T) {
+	tests := []struct {
+		input TrajectoryInput
+		distance float64
+	}{
+		{TrajectoryInput{Mass: 0.01, SightHeight: 0.05, Velocity: 800}, 100},
+		{TrajectoryInput{BallisticCoefficient: 0.4 * BALLISTIC_COEFFICIENT_FROM_LBPIN2_TO_KGPM2, Drag: DRAG_TABLE_G1.Coefficient, Mass: 0.01, SightHeight: 0.038, Velocity: 850}, 200},
+		{TrajectoryInput{Mass: 0.001, SightHeight: 0.02, Velocity: 90}, 25},
+	}
+
+	for _, test := range tests {
+		angle, found := test.input.ZeroAngle(test.distance)
+		if ! found {
+			t.Errorf("ZeroAngle(%g) at %g m/s not found", test.distance, test.input.Velocity)
+			continue
+		}
+
+		test.input.Angle = angle
+		point, _ := test.input.AtDistance(test.distance)
+		if math.Abs(point.Height) > 1e-4 {
+			t.Errorf("Height at the %g m zero range = %g m, expected 0", test.distance, point.Height)
+		}
+	}
+
+	input := TrajectoryInput{Mass: 0.01, Velocity: 50}
+	if angle, found := input.ZeroAngle(1000); found {
+		t.Errorf("ZeroAngle(1000) beyond the %g m range found %g rad", 50 * 50 / GRAVITY_MPS, angle)
+	}
+}
+
+
+func TestPointBlankAngle(t *testing.T) {
+	input := TrajectoryInput{BallisticCoefficient: 0.4 * BALLISTIC_COEFFICIENT_FROM_LBPIN2_TO_KGPM2, Drag: DRAG_TABLE_G1.Coefficient, Mass: 0.01, SightHeight: 0.038, Velocity: 850}
+	radius := 0.1
+
+	input.Angle = input.PointBlankAngle(radius)
+	zero := input.Zero(radius)
+	if ! closeTo(zero.Apex.Height, radius, 1e-3) {
+		t.Errorf("Apex at the point blank angle = %g m, expected the %g m radius", zero.Apex.Height, radius)
+	}
+	if zero.PointBlank.Distance <= zero.FarZero.Distance || zero.FarZero.Distance <= zero.NearZero.Distance {
+		t.Errorf("Zeros out of order: near %g m, far %g m, point blank %g m", zero.NearZero.Distance, zero.FarZero.Distance, zero.PointBlank.Distance)
+	}
+}
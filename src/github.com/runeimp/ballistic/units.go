@@ -0,0 +1,317 @@
+/**
+ * Ballistic.units
+ */
+
+//
+// PACKAGES
+//
+package ballistic
+
+
+//
+// IMPORTS
+//
+import (
+	"fmt"
+	"strings"
+)
+
+
+//
+// Structs
+//
+
+/**
+ * A measurement in the internal units of its dimension
+ *
+ * The dimension is the value type such as VALUE_TYPE_LENGTH so a quantity can
+ * be converted to any unit of the same value type.
+ */
+type Quantity struct {
+	Dimension string
+	Value float64
+}
+
+
+/**
+ * Units a value may be given in
+ *
+ * Symbols are matched exactly so m is meters while M is nautical miles. Names
+ * are matched ignoring case so Meters and METERS are both meters.
+ */
+type Unit struct {
+	Default bool       // Used when the value has no suffix
+	Designation string // Label of the units the user gave
+	Dimension string   // Value type of the group the units are in. Set from the registry.
+	Factor float64     // Internal units per one of these units
+	Names []string     // Long names matched ignoring case
+	Note string        // Explanation shown in the help after the suffixes
+	Offset float64     // Internal units added after the factor such as for temperatures
+	Symbols []string   // Symbols matched exactly
+	System string      // The measurement system the units imply the input is in. Empty for neither.
+}
+
+
+/** Units of one value type with the internal units values are normalized to */
+type UnitGroup struct {
+	Label string // Internal units
+	Title string // Help section title
+	Units []Unit
+	ValueType string
+}
+
+
+//
+// CONSTANTS
+//
+const UNIT_SYSTEM_IMPERIAL = "imperial"
+const UNIT_SYSTEM_INPUT = "input" // Output in units matching the input
+const UNIT_SYSTEM_METRIC = "metric"
+
+
+//
+// VARIABLES
+//
+
+/** Every unit ParseValue accepts by value type ordered by the help section title */
+var UnitRegistry []UnitGroup = []UnitGroup{
+	UnitGroup{Label: "degrees", Title: "ANGLE", ValueType: VALUE_TYPE_ANGLE, Units: []Unit{
+		Unit{Default: true, Designation: ANGLE_LABEL_DEGREES, Factor: 1, Names: []string{"deg", "degree", "degrees"}, Symbols: []string{"°", "d"}},
+		Unit{Designation: ANGLE_LABEL_MILS, Factor: ANGLE_FROM_MILS_TO_DEGREES, Names: []string{"mil", "mils"}, Note: "NATO mils, 6400 to a circle"},
+		Unit{Designation: ANGLE_LABEL_MOA, Factor: ANGLE_FROM_MOA_TO_DEGREES, Names: []string{"moa"}, Note: "Minutes of angle"},
+		Unit{Designation: ANGLE_LABEL_MILLIRADIANS, Factor: ANGLE_FROM_MILLIRADIANS_TO_DEGREES, Names: []string{"mrad", "milliradian", "milliradians"}},
+		Unit{Designation: ANGLE_LABEL_CLOCK, Factor: ANGLE_FROM_CLOCK_TO_DEGREES, Names: []string{"oclock", "o-clock", "clock"}, Note: "Clock face direction, 12 o'clock is straight ahead"},
+		Unit{Designation: ANGLE_LABEL_RADIANS, Factor: ANGLE_FROM_RADIANS_TO_DEGREES, Names: []string{"rad", "radian", "radians"}, Symbols: []string{"r"}},
+		Unit{Designation: ANGLE_LABEL_SMOA, Factor: ANGLE_FROM_SMOA_TO_DEGREES, Names: []string{"smoa", "iphy"}, Note: "Shooter's minutes of angle, 1 inch per hundred yards"},
+	}},
+	UnitGroup{Label: BALLISTIC_COEFFICIENT_LABEL_KGPM2, Title: "BALLISTIC COEFFICIENT", ValueType: VALUE_TYPE_BALLISTIC_COEFFICIENT, Units: []Unit{
+		Unit{Default: true, Designation: BALLISTIC_COEFFICIENT_LABEL_LBPIN2, Factor: BALLISTIC_COEFFICIENT_FROM_LBPIN2_TO_KGPM2, Note: "Pounds per square inch", Symbols: []string{"lb", "lbs"}},
+		Unit{Designation: BALLISTIC_COEFFICIENT_LABEL_KGPM2, Factor: 1, Note: "Kilograms per square meter", Symbols: []string{"kg"}},
+	}},
+	UnitGroup{Label: "joules", Title: "ENERGY", ValueType: VALUE_TYPE_ENERGY, Units: []Unit{
+		Unit{Default: true, Designation: ENERGY_LABEL_JOULES, Factor: 1, Names: []string{"joule", "joules"}, Symbols: []string{"J", "j"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: ENERGY_LABEL_KILOJOULES, Factor: ENERGY_FROM_KILOJOULES_TO_JOULES, Names: []string{"kilojoule", "kilojoules"}, Symbols: []string{"kJ", "kj"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: ENERGY_LABEL_FOOTPOUNDS, Factor: ENERGY_FROM_FOOTPOUNDS_TO_JOULES, Names: []string{"foot-pounds"}, Symbols: []string{"ft-lb", "ft-lbf", "ftlb", "ftlbf"}, System: UNIT_SYSTEM_IMPERIAL},
+	}},
+	UnitGroup{Label: "newtons", Title: "FORCE", ValueType: VALUE_TYPE_FORCE, Units: []Unit{
+		Unit{Default: true, Designation: FORCE_LABEL_NEWTONS, Factor: 1, Names: []string{"newton", "newtons"}, Symbols: []string{"N", "n"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: FORCE_LABEL_KILOGRAMS, Factor: FORCE_FROM_KILOGRAMS_TO_NEWTONS, Names: []string{"kilograms-force"}, Symbols: []string{"kg", "kgf"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: FORCE_LABEL_POUNDS, Factor: FORCE_FROM_POUNDS_TO_NEWTONS, Names: []string{"pounds", "pounds-force"}, Symbols: []string{"#", "lb", "lbs", "lbf"}, System: UNIT_SYSTEM_IMPERIAL},
+	}},
+	UnitGroup{Label: "revolutions per second", Title: "FREQUENCY", ValueType: VALUE_TYPE_FREQUENCY, Units: []Unit{
+		Unit{Default: true, Designation: FREQUENCY_LABEL_RPS, Factor: 1, Names: []string{"rps", "revolutions-per-second"}, Symbols: []string{"Hz", "hz"}},
+		Unit{Designation: FREQUENCY_LABEL_RPM, Factor: FREQUENCY_FROM_RPM_TO_RPS, Names: []string{"rpm", "revolutions-per-minute"}},
+	}},
+	UnitGroup{Label: "meter", Title: "LENGTH", ValueType: VALUE_TYPE_LENGTH, Units: []Unit{
+		Unit{Designation: LENGTH_LABEL_CENTIMETER, Factor: LENGTH_FROM_CENTIMETERS_TO_METERS, Names: []string{"centi", "centimeter", "centimeters"}, Symbols: []string{"c", "cm"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: LENGTH_LABEL_FOOT, Factor: LENGTH_FROM_FEET_TO_METERS, Names: []string{"foot", "feet"}, Symbols: []string{"'", "f", "ft"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: LENGTH_LABEL_INCH, Factor: LENGTH_FROM_INCHES_TO_METERS, Names: []string{"inch", "inches"}, Symbols: []string{"\"", "i", "in"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: LENGTH_LABEL_KILOMETER, Factor: LENGTH_FROM_KILOMETERS_TO_METERS, Names: []string{"kilo", "kilometer", "kilometers"}, Symbols: []string{"k", "km"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Default: true, Designation: LENGTH_LABEL_METER, Factor: 1, Names: []string{"meter", "meters", "metre", "metres"}, Symbols: []string{"m"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: LENGTH_LABEL_NAUTICAL_MILE, Factor: LENGTH_FROM_NAUTICAL_MILES_TO_METERS, Names: []string{"nautical-mile", "nautical-miles"}, Note: "Nautical Miles", Symbols: []string{"M", "NM", "Nm", "nm", "nmi"}},
+		Unit{Designation: LENGTH_LABEL_MILE, Factor: LENGTH_FROM_MILES_TO_METERS, Names: []string{"mile", "miles"}, Symbols: []string{"mi"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: LENGTH_LABEL_MILLIMETER, Factor: LENGTH_FROM_MILLIMETERS_TO_METERS, Names: []string{"milli", "millimeter", "millimeters"}, Symbols: []string{"mm"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: LENGTH_LABEL_YARD, Factor: LENGTH_FROM_YARDS_TO_METERS, Names: []string{"yard", "yards"}, Symbols: []string{"y", "yd", "yrd"}, System: UNIT_SYSTEM_IMPERIAL},
+	}},
+	UnitGroup{Label: "kilograms per meter", Title: "LINEAR DENSITY", ValueType: VALUE_TYPE_LINEAR_DENSITY, Units: []Unit{
+		Unit{Default: true, Designation: LINEAR_DENSITY_LABEL_GPI, Factor: LINEAR_DENSITY_FROM_GPI_TO_KGPM, Names: []string{"gpi", "grains-per-inch"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: LINEAR_DENSITY_LABEL_GPM, Factor: LINEAR_DENSITY_FROM_GPM_TO_KGPM, Names: []string{"gpm", "grams-per-meter"}, Symbols: []string{"g/m"}, System: UNIT_SYSTEM_METRIC},
+	}},
+	UnitGroup{Label: "kilogram", Title: "MASS", ValueType: VALUE_TYPE_MASS, Units: []Unit{
+		Unit{Designation: MASS_LABEL_POUNDS, Factor: MASS_FROM_POUNDS_TO_KILOGRAMS, Names: []string{"pound", "pounds"}, Symbols: []string{"#", "lb", "lbs"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Default: true, Designation: MASS_LABEL_GRAMS, Factor: MASS_FROM_GRAMS_TO_KILOGRAMS, Names: []string{"gram", "grams"}, Symbols: []string{"g"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: MASS_LABEL_GRAINS, Factor: MASS_FROM_GRAINS_TO_KILOGRAMS, Names: []string{"grain", "grains"}, Symbols: []string{"gr"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: MASS_LABEL_KILOGRAMS, Factor: 1, Names: []string{"kilo", "kilogram", "kilograms"}, Symbols: []string{"kg"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: MASS_LABEL_LONG_TON, Factor: MASS_FROM_TONS_LONG_TO_KILOGRAMS, Names: []string{"long-ton"}, Symbols: []string{"lt"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: MASS_LABEL_METRIC_TONNE, Factor: MASS_FROM_TONS_METRIC_TO_KILOGRAMS, Names: []string{"tonne", "metric-tonne"}, Symbols: []string{"t", "mt"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: MASS_LABEL_OUNCES, Factor: MASS_FROM_OUNCES_TO_KILOGRAMS, Names: []string{"ounce", "ounces"}, Symbols: []string{"oz"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: MASS_LABEL_STONE, Factor: MASS_FROM_STONE_TO_KILOGRAMS, Names: []string{"stone"}, Symbols: []string{"st"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: MASS_LABEL_SHORT_TON, Factor: MASS_FROM_TONS_SHORT_TO_KILOGRAMS, Names: []string{"ton", "short-ton"}, System: UNIT_SYSTEM_IMPERIAL},
+	}},
+	UnitGroup{Label: "newton seconds", Title: "MOMENTUM", ValueType: VALUE_TYPE_MOMENTUM, Units: []Unit{
+		Unit{Default: true, Designation: MOMENTUM_LABEL_NS, Factor: 1, Note: "Newton seconds", Symbols: []string{"N·s", "N⋅s", "Ns", "N-s", "n·s", "n⋅s", "ns", "n-s"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: MOMENTUM_LABEL_MKS, Factor: 1, Note: "Kilogram meters per second", Symbols: []string{"kg·m/s", "kg⋅m/s", "kg-m/s", "kgm/s"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: MOMENTUM_LABEL_FPS, Factor: MOMENTUM_FROM_LBFPS_TO_MKS, Note: "Pound feet per second", Symbols: []string{"lb·ft/s", "lb⋅ft/s", "lb-ft/s", "lbft/s"}, System: UNIT_SYSTEM_IMPERIAL},
+	}},
+	UnitGroup{Label: "fraction", Title: "PERCENT", ValueType: VALUE_TYPE_PERCENT, Units: []Unit{
+		Unit{Default: true, Designation: PERCENT_LABEL, Factor: 0.01, Names: []string{"percent"}, Symbols: []string{"%"}},
+	}},
+	UnitGroup{Label: "pascals", Title: "PRESSURE", ValueType: VALUE_TYPE_PRESSURE, Units: []Unit{
+		Unit{Default: true, Designation: PRESSURE_LABEL_HECTOPASCALS, Factor: PRESSURE_FROM_HECTOPASCALS_TO_PASCALS, Names: []string{"hectopascal", "hectopascals"}, Symbols: []string{"hPa", "hpa"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: PRESSURE_LABEL_INCHES_OF_MERCURY, Factor: PRESSURE_FROM_INCHES_OF_MERCURY_TO_PASCALS, Names: []string{"inches-of-mercury"}, Symbols: []string{"inHg", "inhg"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: PRESSURE_LABEL_KILOPASCALS, Factor: PRESSURE_FROM_KILOPASCALS_TO_PASCALS, Names: []string{"kilopascal", "kilopascals"}, Symbols: []string{"kPa", "kpa"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: PRESSURE_LABEL_MILLIBARS, Factor: PRESSURE_FROM_HECTOPASCALS_TO_PASCALS, Names: []string{"millibar", "millibars"}, Symbols: []string{"mb", "mbar"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: PRESSURE_LABEL_MILLIMETERS_OF_MERCURY, Factor: PRESSURE_FROM_MILLIMETERS_OF_MERCURY_TO_PASCALS, Names: []string{"millimeters-of-mercury"}, Symbols: []string{"mmHg", "mmhg"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: PRESSURE_LABEL_PASCALS, Factor: 1, Names: []string{"pascal", "pascals"}, Symbols: []string{"Pa", "pa"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: PRESSURE_LABEL_PSI, Factor: PRESSURE_FROM_PSI_TO_PASCALS, Names: []string{"psi", "pounds-per-square-inch"}, System: UNIT_SYSTEM_IMPERIAL},
+	}},
+	UnitGroup{Label: "kelvin", Title: "TEMPERATURE", ValueType: VALUE_TYPE_TEMPERATURE, Units: []Unit{
+		Unit{Default: true, Designation: TEMPERATURE_LABEL_CELSIUS, Factor: 1, Names: []string{"celsius"}, Offset: TEMPERATURE_FROM_CELSIUS_TO_KELVIN, Symbols: []string{"C", "c", "°C", "°c"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: TEMPERATURE_LABEL_FAHRENHEIT, Factor: TEMPERATURE_FROM_FAHRENHEIT_TO_CELSIUS, Names: []string{"fahrenheit"}, Offset: TEMPERATURE_FROM_CELSIUS_TO_KELVIN - TEMPERATURE_FAHRENHEIT_FREEZING * TEMPERATURE_FROM_FAHRENHEIT_TO_CELSIUS, Symbols: []string{"F", "f", "°F", "°f"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: TEMPERATURE_LABEL_KELVIN, Factor: 1, Names: []string{"kelvin"}, Symbols: []string{"K"}, System: UNIT_SYSTEM_METRIC},
+	}},
+	UnitGroup{Label: "meters per second", Title: "VELOCITY", ValueType: VALUE_TYPE_VELOCITY, Units: []Unit{
+		Unit{Designation: VELOCITY_LABEL_FPS, Factor: VELOCITY_FROM_FPS_TO_MPS, Names: []string{"fps", "feet-per-second"}, Symbols: []string{"ft/s"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Designation: VELOCITY_LABEL_KMPH, Factor: VELOCITY_FROM_KMPH_TO_MPS, Names: []string{"kmph", "kilometers-per-hour"}, Symbols: []string{"k", "km/h"}, System: UNIT_SYSTEM_METRIC},
+		Unit{Designation: VELOCITY_LABEL_KNOTS, Factor: VELOCITY_FROM_KNOTS_TO_MPS, Names: []string{"kn", "kt", "knot", "knots"}},
+		Unit{Designation: VELOCITY_LABEL_MPH, Factor: VELOCITY_FROM_MPH_TO_MPS, Names: []string{"mph", "miles-per-hour"}, System: UNIT_SYSTEM_IMPERIAL},
+		Unit{Default: true, Designation: VELOCITY_LABEL_MPS, Factor: 1, Names: []string{"mps", "meters-per-second"}, Symbols: []string{"m/s"}, System: UNIT_SYSTEM_METRIC},
+	}},
+}
+
+
+//
+// FUNCTIONS
+//
+
+/** Returns the units of the value type */
+func UnitGroupFor(value_type string) (group UnitGroup, found bool) {
+	for _, group = range UnitRegistry {
+		if group.ValueType == value_type {
+			return group, true
+		}
+	}
+	return UnitGroup{}, false
+}
+
+
+/**
+ * Returns the units of the value type for the suffix
+ *
+ * Symbols are matched exactly before names are matched ignoring case. The
+ * default units are returned for an empty suffix.
+ */
+func LookupUnit(value_type, suffix string) (unit Unit, found bool) {
+	group, found := UnitGroupFor(value_type)
+	if ! found {
+		return unit, false
+	}
+
+	for _, unit = range group.Units {
+		if len(suffix) == 0 && unit.Default {
+			return unit, true
+		}
+		for _, symbol := range unit.Symbols {
+			if suffix == symbol {
+				return unit, true
+			}
+		}
+	}
+	for _, unit = range group.Units {
+		for _, name := range unit.Names {
+			if strings.EqualFold(suffix, name) {
+				return unit, true
+			}
+		}
+	}
+
+	return Unit{}, false
+}
+
+
+/** Returns the units of the dimension with the designation such as LENGTH_LABEL_FOOT */
+func UnitByLabel(dimension, designation string) (unit Unit, found bool) {
+	group, _ := UnitGroupFor(dimension)
+	for _, unit = range group.Units {
+		if unit.Designation == designation {
+			return unit, true
+		}
+	}
+
+	return Unit{}, false
+}
+
+
+/** Returns the default units of the dimension */
+func DefaultUnit(dimension string) (unit Unit, found bool) {
+	return LookupUnit(dimension, "")
+}
+
+
+/** Returns every suffix accepted for the value type */
+func UnitSuffixes(value_type string) (suffixes []string) {
+	group, _ := UnitGroupFor(value_type)
+	for _, unit := range group.Units {
+		suffixes = append(suffixes, unit.Symbols...)
+		suffixes = append(suffixes, unit.Names...)
+	}
+	return suffixes
+}
+
+
+/** Normalize the number in these units to the internal units */
+func (unit Unit) Normalize(number float64) float64 {
+	return number * unit.Factor + unit.Offset
+}
+
+
+/** Convert a value in the internal units to a number in these units */
+func (unit Unit) Denormalize(value float64) float64 {
+	return (value - unit.Offset) / unit.Factor
+}
+
+
+/** Returns the quantity of the number in the units */
+func NewQuantity(number float64, unit Unit) Quantity {
+	return Quantity{Dimension: unit.Dimension, Value: unit.Normalize(number)}
+}
+
+
+/** Returns the number of the units in the quantity. The units must be of the same dimension. */
+func (quantity Quantity) In(unit Unit) (number float64, err error) {
+	if quantity.Dimension != unit.Dimension {
+		return 0.0, fmt.Errorf("Can not convert %s to %s", quantity.Dimension, unit.Designation)
+	}
+
+	return unit.Denormalize(quantity.Value), nil
+}
+
+
+/** Returns the VALUE SUFFIXES help section listing every unit in the registry */
+func UnitSuffixesHelp() string {
+	var help strings.Builder
+
+	help.WriteString("VALUE SUFFIXES:\n")
+	help.WriteString("  All input values may be suffixed to allow for broader input selection.\n")
+	help.WriteString("  Symbols are case sensitive (i.e. m is meters and M is nautical miles) while\n")
+	help.WriteString("  names are not (i.e. Meters or METERS).\n")
+	help.WriteString("  Terms in different units are added together (i.e. 5ft3in, 5' 3\" or 1lb 4oz)\n")
+	help.WriteString("  and may be added or subtracted (i.e. 300gr+10gr or 28in-1.5in). Numbers may\n")
+	help.WriteString("  have an exponent (i.e. 2.5e3fps).\n")
+	help.WriteString("\n")
+
+	for _, group := range UnitRegistry {
+		help.WriteString("  " + group.Title + "\n")
+		for _, unit := range group.Units {
+			suffixes := append(append([]string{}, unit.Symbols...), unit.Names...)
+			help.WriteString("    " + strings.Join(suffixes, ", "))
+			if len(unit.Note) > 0 {
+				help.WriteString("  (" + unit.Note + ")")
+			}
+			if unit.Default {
+				help.WriteString(" †")
+			}
+			help.WriteString("\n")
+		}
+	}
+
+	help.WriteString("\n")
+	help.WriteString("†  This is the default and will be used if no suffix is specified\n")
+	help.WriteString("\n")
+	help.WriteString("If most or all of the input values are in imperial units then the output will use imperial units as well.\n")
+	help.WriteString("Set --units or the BALLISTIC_UNITS environment variable to metric or imperial to always output in that system.\n")
+	help.WriteString("\n")
+
+	return help.String()
+}
+
+
+/** Initialize Package */
+func init() {
+	for g, group := range UnitRegistry {
+		for u := range group.Units {
+			UnitRegistry[g].Units[u].Dimension = group.ValueType
+		}
+	}
+}
+
@@ -408,6 +408,12 @@ func NumberFormatter(locale_str string) func(number float64, scale int) (result
 
 		str_whole := float_parts[0] // decimal or integral
 		str_scale := float_parts[1] // fractional
+		str_sign := ""
+
+		if strings.HasPrefix(str_whole, "-") {
+			str_sign = "-"
+			str_whole = str_whole[1:]
+		}
 
 		// log.Printf("NumberFormatter func() | number: %f\n", number)
 		// log.Printf("NumberFormatter func() | str_float: %s\n", str_float)
@@ -447,7 +453,7 @@ func NumberFormatter(locale_str string) func(number float64, scale int) (result
 		}
 
 		if len(result) > 0 {
-			result += separatrix
+			result = str_sign + result + separatrix
 		}
 
 		grouping = locale_data.NumberFormat.Fractional_Grouping